@@ -0,0 +1,99 @@
+package v1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Feature declares a cluster add-on which is applied using the internal Features API, the same way
+// as features of the operator itself. Manifests of the add-on are applied once its preconditions are met,
+// resources created from them are owned by the corresponding FeatureTracker, and they are removed
+// (patches reverted) when the Feature is deleted.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of applying the feature"
+// +kubebuilder:printcolumn:name="Tracker",type=string,JSONPath=`.status.trackerName`,description="FeatureTracker of the feature"
+type Feature struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FeatureSpec   `json:"spec,omitempty"`
+	Status FeatureStatus `json:"status,omitempty"`
+}
+
+// FeatureSpec defines the desired state of Feature.
+type FeatureSpec struct {
+	// TargetNamespace is the namespace in which the feature is applied. It is available in templates as {{ .TargetNamespace }}.
+	// Defaults to the applications namespace of DSCInitialization.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+	// Manifests lists the sources of the manifests applied by the feature. Manifests follow the same naming
+	// conventions as manifests of the operator features, e.g. ".tmpl." for templates and ".patch." for patches.
+	// +kubebuilder:validation:MinItems=1
+	Manifests []ManifestsSource `json:"manifests"`
+	// PreConditions have to be met before the manifests are applied.
+	// +optional
+	PreConditions []FeaturePreCondition `json:"preconditions,omitempty"`
+}
+
+// ManifestsSource points to the location of the manifests. Exactly one of the locations has to be set.
+type ManifestsSource struct {
+	// ConfigMap holding manifests, each key of the ConfigMap is treated as a separate manifest file.
+	// +optional
+	ConfigMap *ConfigMapManifests `json:"configMap,omitempty"`
+	// OCI artifact bundling manifests.
+	// +optional
+	OCI *OCIManifests `json:"oci,omitempty"`
+}
+
+// ConfigMapManifests references a ConfigMap holding manifests.
+type ConfigMapManifests struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// OCIManifests references an OCI artifact, such as quay.io/org/bundle:v1, holding manifests either
+// as tar archive layers or as single-file layers named using "org.opencontainers.image.title" annotation.
+type OCIManifests struct {
+	Image string `json:"image"`
+}
+
+// FeaturePreCondition is a check performed before the feature is applied. Exactly one of the checks has to be set.
+type FeaturePreCondition struct {
+	// OperatorInstalled is the name of the Subscription of the operator which has to be installed.
+	// +optional
+	OperatorInstalled string `json:"operatorInstalled,omitempty"`
+	// CRDExists is the name of the CustomResourceDefinition which has to be established, e.g. envoyfilters.networking.istio.io.
+	// +optional
+	CRDExists string `json:"crdExists,omitempty"`
+}
+
+// FeatureStatus defines the observed state of Feature.
+type FeatureStatus struct {
+	// Phase describes the Phase of applying the feature.
+	Phase string `json:"phase,omitempty"`
+	// +optional
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+	// TrackerName is the name of the FeatureTracker owning resources created by the feature.
+	// +optional
+	TrackerName string `json:"trackerName,omitempty"`
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FeatureList contains a list of Feature.
+type FeatureList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Feature `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&Feature{},
+		&FeatureList{},
+	)
+}
@@ -46,20 +46,29 @@ var ConditionReason = struct {
 	LoadTemplateData,
 	ApplyManifests,
 	PostConditions,
+	DependencyFailed,
+	DependencyNotReady,
+	Migrations,
+	Warnings,
 	FeatureCreated FeatureConditionReason
 }{
-	FailedApplying:   "FailedApplying",
-	PreConditions:    "PreConditions",
-	ResourceCreation: "ResourceCreation",
-	LoadTemplateData: "LoadTemplateData",
-	ApplyManifests:   "ApplyManifests",
-	PostConditions:   "PostConditions",
-	FeatureCreated:   "FeatureCreated",
+	FailedApplying:     "FailedApplying",
+	PreConditions:      "PreConditions",
+	ResourceCreation:   "ResourceCreation",
+	LoadTemplateData:   "LoadTemplateData",
+	ApplyManifests:     "ApplyManifests",
+	PostConditions:     "PostConditions",
+	DependencyFailed:   "DependencyFailed",
+	DependencyNotReady: "DependencyNotReady",
+	Migrations:         "Migrations",
+	Warnings:           "Warnings",
+	FeatureCreated:     "FeatureCreated",
 }
 
 const (
 	ComponentType OwnerType = "Component"
 	DSCIType      OwnerType = "DSCI"
+	FeatureType   OwnerType = "Feature"
 	UnknownType   OwnerType = "Unknown"
 )
 
@@ -91,6 +100,48 @@ type FeatureTrackerStatus struct {
 	Phase string `json:"phase,omitempty"`
 	// +optional
 	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+	// Inventory lists the objects created from the feature manifests during the last successful apply.
+	// Objects which are listed here, but are no longer part of the feature, are removed on the next apply.
+	// +optional
+	Inventory []ResourceReference `json:"inventory,omitempty"`
+	// PatchSnapshots hold the original state of the fields changed by patches applied by the feature.
+	// They are used to revert the patches when the feature is removed.
+	// +optional
+	PatchSnapshots []PatchSnapshot `json:"patchSnapshots,omitempty"`
+	// MigratedVersion is the operator release for which the migrations of the feature have been applied.
+	// Migrations introduced in later releases are applied once the operator is upgraded.
+	// +optional
+	MigratedVersion string `json:"migratedVersion,omitempty"`
+}
+
+// PatchSnapshot holds the original state of the fields of a patched object.
+type PatchSnapshot struct {
+	Target ResourceReference `json:"target"`
+	// Revert is a JSON merge patch restoring the patched fields to the values they had before the feature was applied.
+	Revert string `json:"revert"`
+	// AddedEntries identify entries added by the patches to the lists of the object. Such lists are not restored
+	// as a whole, only the added entries are removed from them.
+	// +optional
+	AddedEntries []ListEntries `json:"addedEntries,omitempty"`
+}
+
+// ListEntries identifies entries of a list by the value of their key field.
+type ListEntries struct {
+	// Field is the path to the list, e.g. ["spec", "techPreview", "meshConfig", "extensionProviders"].
+	Field []string `json:"field"`
+	// Key is the field identifying entries of the list, e.g. name.
+	Key string `json:"key"`
+	// Values of the key field of the entries.
+	Values []string `json:"values"`
+}
+
+// ResourceReference identifies an object in the cluster.
+type ResourceReference struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
 }
 
 // +kubebuilder:object:root=true
@@ -21,9 +21,7 @@ import (
 
 var (
 	ComponentName     = "codeflare"
-	CodeflarePath     = deploy.DefaultManifestPath + "/" + ComponentName + "/default"
 	CodeflareOperator = "codeflare-operator"
-	ParamsPath        = deploy.DefaultManifestPath + "/" + ComponentName + "/manager"
 )
 
 // Verifies that CodeFlare implements ComponentInterface.
@@ -42,31 +40,36 @@ func (c *CodeFlare) Init(ctx context.Context, _ cluster.Platform) error {
 		"codeflare-operator-controller-image": "RELATED_IMAGE_ODH_CODEFLARE_OPERATOR_IMAGE", // no need mcad, embedded in cfo
 	}
 
-	if err := deploy.ApplyParams(ParamsPath, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", CodeflarePath+"/bases")
+	paramsPath := c.paramsPath()
+	if err := deploy.ApplyParams(paramsPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", paramsPath)
 	}
 
 	return nil
 }
 
-func (c *CodeFlare) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(c.DevFlags.Manifests) != 0 {
-		manifestConfig := c.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentName, manifestConfig); err != nil {
+func (c *CodeFlare) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := c.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentName, *manifestConfig); err != nil {
 			return err
 		}
-		// If overlay is defined, update paths
-		defaultKustomizePath := "default"
-		if manifestConfig.SourcePath != "" {
-			defaultKustomizePath = manifestConfig.SourcePath
-		}
-		CodeflarePath = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 	}
 
 	return nil
 }
 
+func (c *CodeFlare) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentName: deploy.ManifestsPath(ComponentName, "default", c.ManifestsOverride(nil)),
+	}
+}
+
+// paramsPath returns the directory holding params.env of the manifests deployed by the component.
+func (c *CodeFlare) paramsPath() string {
+	return filepath.Join(deploy.ManifestsDir(ComponentName, c.ManifestsOverride(nil)), "manager")
+}
+
 func (c *CodeFlare) GetComponentName() string {
 	return ComponentName
 }
@@ -84,7 +87,7 @@ func (c *CodeFlare) ReconcileComponent(ctx context.Context,
 	if enabled {
 		if c.DevFlags != nil {
 			// Download manifests and update paths
-			if err := c.OverrideManifests(ctx, platform); err != nil {
+			if err := c.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
 		}
@@ -100,14 +103,14 @@ func (c *CodeFlare) ReconcileComponent(ctx context.Context,
 		}
 
 		// It updates stock manifests, overridden manifests should contain proper namespace
-		if err := deploy.ApplyParams(ParamsPath, nil, map[string]string{"namespace": dscispec.ApplicationsNamespace}); err != nil {
-			return fmt.Errorf("failed update image from %s : %w", CodeflarePath+"/bases", err)
+		if err := deploy.ApplyParams(c.paramsPath(), nil, map[string]string{"namespace": dscispec.ApplicationsNamespace}); err != nil {
+			return fmt.Errorf("failed update image from %s : %w", c.paramsPath(), err)
 		}
 	}
 
 	// Deploy Codeflare
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, //nolint:revive,nolintlint
-		CodeflarePath,
+		c.ManifestPaths(platform)[ComponentName],
 		dscispec.ApplicationsNamespace,
 		ComponentName, enabled); err != nil {
 		return err
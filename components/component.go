@@ -3,7 +3,6 @@ package components
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -15,6 +14,7 @@ import (
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy/overlay"
 )
 
 // Component struct defines the basis for each OpenDataHub component configuration.
@@ -62,11 +62,26 @@ type DevFlags struct {
 
 type ManifestsConfig struct {
 	// uri is the URI point to a git repo with tag/branch. e.g.  https://github.com/org/repo/tarball/<tag/branch>
+	// Other supported sources are OCI artifacts (oci://quay.io/org/manifests:tag), git repositories pinned
+	// to a commit (git+https://github.com/org/repo.git#<commit sha>) and local directories (file:///mnt/manifests).
 	// +optional
 	// +kubebuilder:default:=""
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
 	URI string `json:"uri,omitempty"`
 
+	// digest is the expected sha256 digest of the tarball or the OCI artifact manifest, e.g. sha256:<hex>.
+	// When set, fetched manifests are verified against it and reused from the cache without fetching them again.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(sha256:[a-f0-9]{64})?$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	Digest string `json:"digest,omitempty"`
+
+	// authSecret is the name of the Secret in the operator namespace holding credentials for the source,
+	// either "username" and "password" or "token" keys.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	AuthSecret string `json:"authSecret,omitempty"`
+
 	// contextDir is the relative path to the folder containing manifests in a repository, default value "manifests"
 	// +optional
 	// +kubebuilder:default:="manifests"
@@ -80,6 +95,40 @@ type ManifestsConfig struct {
 	SourcePath string `json:"sourcePath,omitempty"`
 }
 
+// ManifestPaths holds kustomize directories of the manifests deployed by a component, keyed by the name of the
+// component or of its dependent component the manifests belong to. They are resolved for every reconciliation from
+// the platform, the shipped defaults and DevFlags, so removing an override takes effect without restarting the operator.
+type ManifestPaths map[string]string
+
+// ManifestsOverride returns the first DevFlags manifests entry accepted by the filter, or nil when there is none.
+// Nil filter accepts any entry.
+func (c *Component) ManifestsOverride(accept func(ManifestsConfig) bool) *ManifestsConfig {
+	if c.DevFlags == nil {
+		return nil
+	}
+
+	for i := range c.DevFlags.Manifests {
+		if accept == nil || accept(c.DevFlags.Manifests[i]) {
+			return &c.DevFlags.Manifests[i]
+		}
+	}
+
+	return nil
+}
+
+// URIContains accepts DevFlags manifests entries whose URI contains the name, e.g. of the repository.
+func URIContains(name string) func(ManifestsConfig) bool {
+	return func(manifestsConfig ManifestsConfig) bool {
+		return strings.Contains(manifestsConfig.URI, name)
+	}
+}
+
+// ManifestsLabeler is implemented by components whose manifests are deployed with the app.opendatahub.io/<component>
+// label other than their name on some platforms.
+type ManifestsLabeler interface {
+	ManifestsLabel(platform cluster.Platform) string
+}
+
 type ComponentInterface interface {
 	Init(ctx context.Context, platform cluster.Platform) error
 	ReconcileComponent(ctx context.Context, cli client.Client,
@@ -87,15 +136,27 @@ type ComponentInterface interface {
 	Cleanup(ctx context.Context, cli client.Client, owner metav1.Object, DSCISpec *dsciv1.DSCInitializationSpec) error
 	GetComponentName() string
 	GetManagementState() operatorv1.ManagementState
-	OverrideManifests(ctx context.Context, platform cluster.Platform) error
+	OverrideManifests(ctx context.Context, cli client.Client, platform cluster.Platform) error
+	ManifestPaths(platform cluster.Platform) ManifestPaths
 	UpdatePrometheusConfig(cli client.Client, logger logr.Logger, enable bool, component string) error
 }
 
 // UpdatePrometheusConfig update prometheus-configs.yaml to include/exclude <component>.rules
 // parameter enable when set to true to add new rules, when set to false to remove existing rules.
+// The file on disk is left intact, rules of the component are set in overlay.Manifests replacing previous ones.
 func (c *Component) UpdatePrometheusConfig(_ client.Client, logger logr.Logger, enable bool, component string) error {
 	prometheusconfigPath := filepath.Join("/opt/manifests", "monitoring", "prometheus", "apps", "prometheus-configs.yaml")
 
+	if !enable {
+		logger.Info("Removing prometheus rule: " + component + "*.rules")
+	}
+
+	return overlay.Manifests.Set(prometheusconfigPath, "rules/"+component, func(yamlData []byte) ([]byte, error) {
+		return updatePrometheusRules(yamlData, enable, component)
+	})
+}
+
+func updatePrometheusRules(yamlData []byte, enable bool, component string) ([]byte, error) {
 	// create a struct to mock poremtheus.yml
 	type ConfigMap struct {
 		APIVersion string `yaml:"apiVersion"`
@@ -137,18 +198,13 @@ func (c *Component) UpdatePrometheusConfig(_ client.Client, logger logr.Logger,
 	// prometheusContent will represent content of prometheus.yml due to its dynamic struct
 	var prometheusContent map[interface{}]interface{}
 
-	// read prometheus.yml from local disk /opt/mainfests/monitoring/prometheus/apps/
-	yamlData, err := os.ReadFile(prometheusconfigPath)
-	if err != nil {
-		return err
-	}
 	if err := yaml.Unmarshal(yamlData, &configMap); err != nil {
-		return err
+		return nil, err
 	}
 
 	// get prometheus.yml part from configmap
 	if err := yaml.Unmarshal([]byte(configMap.Data.PrometheusYML), &prometheusContent); err != nil {
-		return err
+		return nil, err
 	}
 
 	// to add component rules when it is not there yet
@@ -165,7 +221,6 @@ func (c *Component) UpdatePrometheusConfig(_ client.Client, logger logr.Logger,
 			}
 		}
 	} else { // to remove component rules if it is there
-		logger.Info("Removing prometheus rule: " + component + "*.rules")
 		if ruleList, ok := prometheusContent["rule_files"].([]interface{}); ok {
 			for i, item := range ruleList {
 				if rule, isStr := item.(string); isStr && rule == component+"*.rules" {
@@ -181,17 +236,9 @@ func (c *Component) UpdatePrometheusConfig(_ client.Client, logger logr.Logger,
 	// Marshal back
 	newDataYAML, err := yaml.Marshal(&prometheusContent)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	configMap.Data.PrometheusYML = string(newDataYAML)
 
-	newyamlData, err := yaml.Marshal(&configMap)
-	if err != nil {
-		return err
-	}
-
-	// Write the modified content back to the file
-	err = os.WriteFile(prometheusconfigPath, newyamlData, 0)
-
-	return err
+	return yaml.Marshal(&configMap)
 }
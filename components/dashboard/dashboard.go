@@ -24,15 +24,16 @@ import (
 )
 
 var (
-	ComponentNameUpstream = "dashboard"
-	PathUpstream          = deploy.DefaultManifestPath + "/" + ComponentNameUpstream + "/odh"
-
+	ComponentNameUpstream   = "dashboard"
 	ComponentNameDownstream = "rhods-dashboard"
-	PathDownstream          = deploy.DefaultManifestPath + "/" + ComponentNameUpstream + "/rhoai"
-	PathSelfDownstream      = PathDownstream + "/onprem"
-	PathManagedDownstream   = PathDownstream + "/addon"
-	OverridePath            = ""
-	DefaultPath             = ""
+
+	// defaultPaths holds kustomize directories of the dashboard manifests for each platform.
+	defaultPaths = map[cluster.Platform]string{
+		cluster.SelfManagedRhoai: "rhoai/onprem",
+		cluster.ManagedRhoai:     "rhoai/addon",
+		cluster.OpenDataHub:      "odh",
+		cluster.Unknown:          "odh",
+	}
 )
 
 // Verifies that Dashboard implements ComponentInterface.
@@ -50,34 +51,40 @@ func (d *Dashboard) Init(ctx context.Context, platform cluster.Platform) error {
 	imageParamMap := map[string]string{
 		"odh-dashboard-image": "RELATED_IMAGE_ODH_DASHBOARD_IMAGE",
 	}
-	DefaultPath = map[cluster.Platform]string{
-		cluster.SelfManagedRhoai: PathDownstream + "/onprem",
-		cluster.ManagedRhoai:     PathDownstream + "/addon",
-		cluster.OpenDataHub:      PathUpstream,
-		cluster.Unknown:          PathUpstream,
-	}[platform]
-
-	if err := deploy.ApplyParams(DefaultPath, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", DefaultPath)
+	entryPath := d.ManifestPaths(platform)[ComponentNameUpstream]
+	if err := deploy.ApplyParams(entryPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", entryPath)
 	}
 
 	return nil
 }
 
-func (d *Dashboard) OverrideManifests(ctx context.Context, platform cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(d.DevFlags.Manifests) != 0 {
-		manifestConfig := d.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentNameUpstream, manifestConfig); err != nil {
+func (d *Dashboard) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := d.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentNameUpstream, *manifestConfig); err != nil {
 			return err
 		}
-		if manifestConfig.SourcePath != "" {
-			OverridePath = filepath.Join(deploy.DefaultManifestPath, ComponentNameUpstream, manifestConfig.SourcePath)
-		}
 	}
 	return nil
 }
 
+func (d *Dashboard) ManifestPaths(platform cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentNameUpstream: deploy.ManifestsPath(ComponentNameUpstream, defaultPaths[platform], d.ManifestsOverride(nil)),
+	}
+}
+
+// ManifestsLabel returns the component name the manifests are deployed with on the platform.
+func (d *Dashboard) ManifestsLabel(platform cluster.Platform) string {
+	switch platform {
+	case cluster.SelfManagedRhoai, cluster.ManagedRhoai:
+		return ComponentNameDownstream
+	default:
+		return ComponentNameUpstream
+	}
+}
+
 func (d *Dashboard) GetComponentName() string {
 	return ComponentNameUpstream
 }
@@ -89,7 +96,7 @@ func (d *Dashboard) ReconcileComponent(ctx context.Context,
 	platform cluster.Platform,
 	currentComponentExist bool,
 ) error {
-	entryPath := DefaultPath
+	entryPath := d.ManifestPaths(platform)[ComponentNameUpstream]
 	l := logf.FromContext(ctx)
 	enabled := d.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
@@ -100,13 +107,11 @@ func (d *Dashboard) ReconcileComponent(ctx context.Context,
 			return err
 		}
 		if d.DevFlags != nil && len(d.DevFlags.Manifests) != 0 {
-			// Download manifests and update paths
-			if err := d.OverrideManifests(ctx, platform); err != nil {
+			// Download manifests and use them from now on
+			if err := d.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
-			if OverridePath != "" {
-				entryPath = OverridePath
-			}
+			entryPath = d.ManifestPaths(platform)[ComponentNameUpstream]
 		}
 
 		// 2. platform specific RBAC
@@ -142,7 +147,7 @@ func (d *Dashboard) ReconcileComponent(ctx context.Context,
 		}
 		// Deploy RHOAI manifests
 		if err := deploy.DeployManifestsFromPath(ctx, cli, owner, entryPath, dscispec.ApplicationsNamespace, ComponentNameDownstream, enabled); err != nil {
-			return fmt.Errorf("failed to apply manifests from %s: %w", PathDownstream, err)
+			return fmt.Errorf("failed to apply manifests from %s: %w", entryPath, err)
 		}
 		l.Info("apply manifests done")
 
@@ -27,8 +27,6 @@ import (
 
 var (
 	ComponentName   = "data-science-pipelines-operator"
-	Path            = deploy.DefaultManifestPath + "/" + ComponentName + "/base"
-	OverlayPath     = deploy.DefaultManifestPath + "/" + ComponentName + "/overlays"
 	ArgoWorkflowCRD = "workflows.argoproj.io"
 )
 
@@ -56,31 +54,37 @@ func (d *DataSciencePipelines) Init(ctx context.Context, _ cluster.Platform) err
 		"IMAGES_MLMDGRPC":                "RELATED_IMAGE_ODH_MLMD_GRPC_SERVER_IMAGE",
 	}
 
-	if err := deploy.ApplyParams(Path, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", Path)
+	paramsPath := filepath.Join(deploy.DefaultManifestPath, ComponentName, "base")
+	if err := deploy.ApplyParams(paramsPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", paramsPath)
 	}
 
 	return nil
 }
 
-func (d *DataSciencePipelines) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(d.DevFlags.Manifests) != 0 {
-		manifestConfig := d.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentName, manifestConfig); err != nil {
+func (d *DataSciencePipelines) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := d.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentName, *manifestConfig); err != nil {
 			return err
 		}
-		// If overlay is defined, update paths
-		defaultKustomizePath := "base"
-		if manifestConfig.SourcePath != "" {
-			defaultKustomizePath = manifestConfig.SourcePath
-		}
-		Path = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 	}
 
 	return nil
 }
 
+func (d *DataSciencePipelines) ManifestPaths(platform cluster.Platform) components.ManifestPaths {
+	// new overlay
+	overlay := "overlays/rhoai"
+	if platform == cluster.OpenDataHub || platform == "" {
+		overlay = "overlays/odh"
+	}
+
+	return components.ManifestPaths{
+		ComponentName: deploy.ManifestsPath(ComponentName, overlay, d.ManifestsOverride(nil)),
+	}
+}
+
 func (d *DataSciencePipelines) GetComponentName() string {
 	return ComponentName
 }
@@ -99,7 +103,7 @@ func (d *DataSciencePipelines) ReconcileComponent(ctx context.Context,
 	if enabled {
 		if d.DevFlags != nil {
 			// Download manifests and update paths
-			if err := d.OverrideManifests(ctx, platform); err != nil {
+			if err := d.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
 		}
@@ -110,11 +114,7 @@ func (d *DataSciencePipelines) ReconcileComponent(ctx context.Context,
 		}
 	}
 
-	// new overlay
-	manifestsPath := filepath.Join(OverlayPath, "rhoai")
-	if platform == cluster.OpenDataHub || platform == "" {
-		manifestsPath = filepath.Join(OverlayPath, "odh")
-	}
+	manifestsPath := d.ManifestPaths(platform)[ComponentName]
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, manifestsPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
 		return err
 	}
@@ -5,7 +5,6 @@ package kserve
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -22,9 +21,7 @@ import (
 
 var (
 	ComponentName          = "kserve"
-	Path                   = deploy.DefaultManifestPath + "/" + ComponentName + "/overlays/odh"
 	DependentComponentName = "odh-model-controller"
-	DependentPath          = deploy.DefaultManifestPath + "/" + DependentComponentName + "/base"
 	ServiceMeshOperator    = "servicemeshoperator"
 	ServerlessOperator     = "serverless-operator"
 )
@@ -58,7 +55,7 @@ type Kserve struct {
 	NIM infrav1.NimSpec `json:"nim,omitempty"`
 }
 
-func (k *Kserve) Init(ctx context.Context, _ cluster.Platform) error {
+func (k *Kserve) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
 	// dependentParamMap for odh-model-controller to use.
@@ -66,47 +63,34 @@ func (k *Kserve) Init(ctx context.Context, _ cluster.Platform) error {
 		"odh-model-controller": "RELATED_IMAGE_ODH_MODEL_CONTROLLER_IMAGE",
 	}
 	// Update image parameters for odh-model-controller
-	if err := deploy.ApplyParams(DependentPath, dependentParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", DependentPath)
+	dependentPath := k.ManifestPaths(platform)[DependentComponentName]
+	if err := deploy.ApplyParams(dependentPath, dependentParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", dependentPath)
 	}
 
 	return nil
 }
 
-func (k *Kserve) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// Download manifests if defined by devflags
-	// Go through each manifest and set the overlays if defined
-	for _, subcomponent := range k.DevFlags.Manifests {
-		if strings.Contains(subcomponent.URI, DependentComponentName) {
-			// Download subcomponent
-			if err := deploy.DownloadManifests(ctx, DependentComponentName, subcomponent); err != nil {
+func (k *Kserve) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// Download manifests if defined by devflags, ManifestPaths picks the overlays
+	for _, name := range []string{DependentComponentName, ComponentName} {
+		if subcomponent := k.ManifestsOverride(components.URIContains(name)); subcomponent != nil {
+			if err := deploy.DownloadManifests(ctx, cli, name, *subcomponent); err != nil {
 				return err
 			}
-			// If overlay is defined, update paths
-			defaultKustomizePath := "base"
-			if subcomponent.SourcePath != "" {
-				defaultKustomizePath = subcomponent.SourcePath
-			}
-			DependentPath = filepath.Join(deploy.DefaultManifestPath, DependentComponentName, defaultKustomizePath)
-		}
-
-		if strings.Contains(subcomponent.URI, ComponentName) {
-			// Download subcomponent
-			if err := deploy.DownloadManifests(ctx, ComponentName, subcomponent); err != nil {
-				return err
-			}
-			// If overlay is defined, update paths
-			defaultKustomizePath := "overlays/odh"
-			if subcomponent.SourcePath != "" {
-				defaultKustomizePath = subcomponent.SourcePath
-			}
-			Path = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 		}
 	}
 
 	return nil
 }
 
+func (k *Kserve) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentName:          deploy.ManifestsPath(ComponentName, "overlays/odh", k.ManifestsOverride(components.URIContains(ComponentName))),
+		DependentComponentName: deploy.ManifestsPath(DependentComponentName, "base", k.ManifestsOverride(components.URIContains(DependentComponentName))),
+	}
+}
+
 func (k *Kserve) GetComponentName() string {
 	return ComponentName
 }
@@ -116,9 +100,10 @@ func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 	l := logf.FromContext(ctx)
 	enabled := k.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
+	paths := k.ManifestPaths(platform)
 
 	if !enabled {
-		if err := deploy.ApplyParams(DependentPath, nil, map[string]string{"nim-state": "removed"}); err != nil {
+		if err := deploy.ApplyParams(paths[DependentComponentName], nil, map[string]string{"nim-state": "removed"}); err != nil {
 			return fmt.Errorf("failed to update NIM flag to removed : %w", err)
 		}
 		if err := k.removeServerlessFeatures(ctx, cli, owner, dscispec); err != nil {
@@ -131,15 +116,16 @@ func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 		}
 		if k.DevFlags != nil {
 			// Download manifests and update paths
-			if err := k.OverrideManifests(ctx, platform); err != nil {
+			if err := k.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
+			paths = k.ManifestPaths(platform)
 		}
 		extraParamsMap := map[string]string{
 			"nim-state": string(k.NIM.ManagementState),
 		}
-		if err := deploy.ApplyParams(DependentPath, nil, extraParamsMap); err != nil {
-			return fmt.Errorf("failed to update NIM flag from %s : %w", Path, err)
+		if err := deploy.ApplyParams(paths[DependentComponentName], nil, extraParamsMap); err != nil {
+			return fmt.Errorf("failed to update NIM flag from %s : %w", paths[DependentComponentName], err)
 		}
 	}
 
@@ -147,11 +133,11 @@ func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 		return fmt.Errorf("failed configuring service mesh while reconciling kserve component. cause: %w", err)
 	}
 
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
-		return fmt.Errorf("failed to apply manifests from %s : %w", Path, err)
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, paths[ComponentName], dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+		return fmt.Errorf("failed to apply manifests from %s : %w", paths[ComponentName], err)
 	}
 
-	l.WithValues("Path", Path).Info("apply manifests done for kserve")
+	l.WithValues("Path", paths[ComponentName]).Info("apply manifests done for kserve")
 
 	if enabled {
 		if err := k.setupKserveConfig(ctx, cli, l, dscispec); err != nil {
@@ -164,13 +150,13 @@ func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 		}
 	}
 
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, DependentPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, paths[DependentComponentName], dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
 		if !strings.Contains(err.Error(), "spec.selector") || !strings.Contains(err.Error(), "field is immutable") {
 			// explicitly ignore error if error contains keywords "spec.selector" and "field is immutable" and return all other error.
 			return err
 		}
 	}
-	l.WithValues("Path", Path).Info("apply manifests done for odh-model-controller")
+	l.WithValues("Path", paths[DependentComponentName]).Info("apply manifests done for odh-model-controller")
 
 	// Wait for deployment available
 	if enabled {
@@ -35,6 +35,7 @@ func (k *Kserve) configureServerlessFeatures(dsciSpec *dsciv1.DSCInitializationS
 			)
 
 		istioSecretFiltering := feature.Define("serverless-net-istio-secret-filtering").
+			DependsOn("serverless-serving-deployment").
 			Manifests(
 				manifest.Location(Resources.Location).
 					Include(
@@ -48,6 +49,7 @@ func (k *Kserve) configureServerlessFeatures(dsciSpec *dsciv1.DSCInitializationS
 			)
 
 		servingGateway := feature.Define("serverless-serving-gateways").
+			DependsOn("serverless-serving-deployment").
 			Manifests(
 				manifest.Location(Resources.Location).
 					Include(
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path"
 
+	"github.com/blang/semver/v4"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,9 +53,15 @@ func (k *Kserve) defineServiceMeshFeatures(ctx context.Context, cli client.Clien
 							path.Join(Resources.ServiceMeshDir, "activator-envoyfilter.tmpl.yaml"),
 							path.Join(Resources.ServiceMeshDir, "envoy-oauth-temp-fix.tmpl.yaml"),
 							path.Join(Resources.ServiceMeshDir, "kserve-predictor-authorizationpolicy.tmpl.yaml"),
-							path.Join(Resources.ServiceMeshDir, "z-migrations"),
 						),
 				).
+				// Authorization provider of predictors created by older releases has to point to the current extension
+				Migrations(semver.MustParse("2.11.0"), feature.ApplyManifests(
+					manifest.Location(Resources.Location).
+						Include(
+							path.Join(Resources.ServiceMeshDir, "migrations", "kserve-predictor-authorizationpolicy.patch.tmpl.yaml"),
+						),
+				)).
 				Managed().
 				WithData(
 					feature.Entry("Domain", cluster.GetDomain),
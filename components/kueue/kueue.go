@@ -19,7 +19,6 @@ import (
 
 var (
 	ComponentName = "kueue"
-	Path          = deploy.DefaultManifestPath + "/" + ComponentName + "/rhoai" // same path for both odh and rhoai
 )
 
 // Verifies that Kueue implements ComponentInterface.
@@ -31,38 +30,38 @@ type Kueue struct {
 	components.Component `json:""`
 }
 
-func (k *Kueue) Init(ctx context.Context, _ cluster.Platform) error {
+func (k *Kueue) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
 	var imageParamMap = map[string]string{
 		"odh-kueue-controller-image": "RELATED_IMAGE_ODH_KUEUE_CONTROLLER_IMAGE", // new kueue image
 	}
 
-	if err := deploy.ApplyParams(Path, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", Path)
+	manifestsPath := k.ManifestPaths(platform)[ComponentName]
+	if err := deploy.ApplyParams(manifestsPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", manifestsPath)
 	}
 
 	return nil
 }
 
-func (k *Kueue) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(k.DevFlags.Manifests) != 0 {
-		manifestConfig := k.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentName, manifestConfig); err != nil {
+func (k *Kueue) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := k.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentName, *manifestConfig); err != nil {
 			return err
 		}
-		// If overlay is defined, update paths
-		defaultKustomizePath := "rhoai"
-		if manifestConfig.SourcePath != "" {
-			defaultKustomizePath = manifestConfig.SourcePath
-		}
-		Path = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 	}
 
 	return nil
 }
 
+func (k *Kueue) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentName: deploy.ManifestsPath(ComponentName, "rhoai", k.ManifestsOverride(nil)), // same path for both odh and rhoai
+	}
+}
+
 func (k *Kueue) GetComponentName() string {
 	return ComponentName
 }
@@ -72,17 +71,19 @@ func (k *Kueue) ReconcileComponent(ctx context.Context, cli client.Client,
 	l := logf.FromContext(ctx)
 	enabled := k.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
+	manifestsPath := k.ManifestPaths(platform)[ComponentName]
 	if enabled {
 		if k.DevFlags != nil {
 			// Download manifests and update paths
-			if err := k.OverrideManifests(ctx, platform); err != nil {
+			if err := k.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
+			manifestsPath = k.ManifestPaths(platform)[ComponentName]
 		}
 	}
 	// Deploy Kueue Operator
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
-		return fmt.Errorf("failed to apply manifests %s: %w", Path, err)
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, manifestsPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+		return fmt.Errorf("failed to apply manifests %s: %w", manifestsPath, err)
 	}
 	l.Info("apply manifests done")
 
@@ -23,9 +23,7 @@ import (
 
 var (
 	ComponentName          = "model-mesh"
-	Path                   = deploy.DefaultManifestPath + "/" + ComponentName + "/overlays/odh"
 	DependentComponentName = "odh-model-controller"
-	DependentPath          = deploy.DefaultManifestPath + "/" + DependentComponentName + "/base"
 )
 
 // Verifies that Dashboard implements ComponentInterface.
@@ -37,7 +35,7 @@ type ModelMeshServing struct {
 	components.Component `json:""`
 }
 
-func (m *ModelMeshServing) Init(ctx context.Context, _ cluster.Platform) error {
+func (m *ModelMeshServing) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
 	var imageParamMap = map[string]string{
@@ -52,51 +50,40 @@ func (m *ModelMeshServing) Init(ctx context.Context, _ cluster.Platform) error {
 		"odh-model-controller": "RELATED_IMAGE_ODH_MODEL_CONTROLLER_IMAGE",
 	}
 
+	paths := m.ManifestPaths(platform)
+
 	// Update image parameters
-	if err := deploy.ApplyParams(Path, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", Path)
+	if err := deploy.ApplyParams(paths[ComponentName], imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", paths[ComponentName])
 	}
 
 	// Update image parameters for odh-model-controller
-	if err := deploy.ApplyParams(DependentPath, dependentImageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", DependentPath)
+	if err := deploy.ApplyParams(paths[DependentComponentName], dependentImageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", paths[DependentComponentName])
 	}
 
 	return nil
 }
 
-func (m *ModelMeshServing) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// Go through each manifest and set the overlays if defined
-	for _, subcomponent := range m.DevFlags.Manifests {
-		if strings.Contains(subcomponent.URI, DependentComponentName) {
-			// Download subcomponent
-			if err := deploy.DownloadManifests(ctx, DependentComponentName, subcomponent); err != nil {
-				return err
-			}
-			// If overlay is defined, update paths
-			defaultKustomizePath := "base"
-			if subcomponent.SourcePath != "" {
-				defaultKustomizePath = subcomponent.SourcePath
-			}
-			DependentPath = filepath.Join(deploy.DefaultManifestPath, DependentComponentName, defaultKustomizePath)
-		}
-
-		if strings.Contains(subcomponent.URI, ComponentName) {
-			// Download subcomponent
-			if err := deploy.DownloadManifests(ctx, ComponentName, subcomponent); err != nil {
+func (m *ModelMeshServing) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// Download manifests if defined by devflags, ManifestPaths picks the overlays
+	for _, name := range []string{DependentComponentName, ComponentName} {
+		if subcomponent := m.ManifestsOverride(components.URIContains(name)); subcomponent != nil {
+			if err := deploy.DownloadManifests(ctx, cli, name, *subcomponent); err != nil {
 				return err
 			}
-			// If overlay is defined, update paths
-			defaultKustomizePath := "overlays/odh"
-			if subcomponent.SourcePath != "" {
-				defaultKustomizePath = subcomponent.SourcePath
-			}
-			Path = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 		}
 	}
 	return nil
 }
 
+func (m *ModelMeshServing) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentName:          deploy.ManifestsPath(ComponentName, "overlays/odh", m.ManifestsOverride(components.URIContains(ComponentName))),
+		DependentComponentName: deploy.ManifestsPath(DependentComponentName, "base", m.ManifestsOverride(components.URIContains(DependentComponentName))),
+	}
+}
+
 func (m *ModelMeshServing) GetComponentName() string {
 	return ComponentName
 }
@@ -111,14 +98,16 @@ func (m *ModelMeshServing) ReconcileComponent(ctx context.Context,
 	l := logf.FromContext(ctx)
 	enabled := m.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
+	paths := m.ManifestPaths(platform)
 
 	// Update Default rolebinding
 	if enabled {
 		if m.DevFlags != nil {
 			// Download manifests and update paths
-			if err := m.OverrideManifests(ctx, platform); err != nil {
+			if err := m.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
+			paths = m.ManifestPaths(platform)
 		}
 
 		if err := cluster.UpdatePodSecurityRolebinding(ctx, cli, dscispec.ApplicationsNamespace,
@@ -131,14 +120,14 @@ func (m *ModelMeshServing) ReconcileComponent(ctx context.Context,
 	extraParamsMap := map[string]string{
 		"nim-state": getNimManagementFlag(owner),
 	}
-	if err := deploy.ApplyParams(DependentPath, nil, extraParamsMap); err != nil {
-		return fmt.Errorf("failed to update image from %s : %w", Path, err)
+	if err := deploy.ApplyParams(paths[DependentComponentName], nil, extraParamsMap); err != nil {
+		return fmt.Errorf("failed to update image from %s : %w", paths[DependentComponentName], err)
 	}
 
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
-		return fmt.Errorf("failed to apply manifests from %s : %w", Path, err)
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, paths[ComponentName], dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+		return fmt.Errorf("failed to apply manifests from %s : %w", paths[ComponentName], err)
 	}
-	l.WithValues("Path", Path).Info("apply manifests done for modelmesh")
+	l.WithValues("Path", paths[ComponentName]).Info("apply manifests done for modelmesh")
 	// For odh-model-controller
 	if enabled {
 		if err := cluster.UpdatePodSecurityRolebinding(ctx, cli, dscispec.ApplicationsNamespace,
@@ -146,14 +135,14 @@ func (m *ModelMeshServing) ReconcileComponent(ctx context.Context,
 			return err
 		}
 	}
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, DependentPath, dscispec.ApplicationsNamespace, m.GetComponentName(), enabled); err != nil {
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, paths[DependentComponentName], dscispec.ApplicationsNamespace, m.GetComponentName(), enabled); err != nil {
 		// explicitly ignore error if error contains keywords "spec.selector" and "field is immutable" and return all other error.
 		if !strings.Contains(err.Error(), "spec.selector") || !strings.Contains(err.Error(), "field is immutable") {
 			return err
 		}
 	}
 
-	l.WithValues("Path", DependentPath).Info("apply manifests done for odh-model-controller")
+	l.WithValues("Path", paths[DependentComponentName]).Info("apply manifests done for odh-model-controller")
 
 	if enabled {
 		if err := cluster.WaitForDeploymentAvailable(ctx, cli, ComponentName, dscispec.ApplicationsNamespace, 20, 2); err != nil {
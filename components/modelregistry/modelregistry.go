@@ -31,7 +31,6 @@ const DefaultModelRegistryCert = "default-modelregistry-cert"
 var (
 	ComponentName                   = "model-registry-operator"
 	DefaultModelRegistriesNamespace = "odh-model-registries"
-	Path                            = deploy.DefaultManifestPath + "/" + ComponentName + "/overlays/odh"
 	// we should not apply this label to the namespace, as it triggered namspace deletion during operator uninstall
 	// modelRegistryLabels = cluster.WithLabels(
 	//      labels.ODH.OwnedNamespace, "true",
@@ -58,7 +57,7 @@ type ModelRegistry struct {
 	RegistriesNamespace string `json:"registriesNamespace,omitempty"`
 }
 
-func (m *ModelRegistry) Init(ctx context.Context, _ cluster.Platform) error {
+func (m *ModelRegistry) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
 	var imageParamMap = map[string]string{
@@ -67,31 +66,31 @@ func (m *ModelRegistry) Init(ctx context.Context, _ cluster.Platform) error {
 		"IMAGES_REST_SERVICE":           "RELATED_IMAGE_ODH_MODEL_REGISTRY_IMAGE",
 	}
 
-	if err := deploy.ApplyParams(Path, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", Path)
+	manifestsPath := m.ManifestPaths(platform)[ComponentName]
+	if err := deploy.ApplyParams(manifestsPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", manifestsPath)
 	}
 
 	return nil
 }
 
-func (m *ModelRegistry) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(m.DevFlags.Manifests) != 0 {
-		manifestConfig := m.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentName, manifestConfig); err != nil {
+func (m *ModelRegistry) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := m.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentName, *manifestConfig); err != nil {
 			return err
 		}
-		// If overlay is defined, update paths
-		defaultKustomizePath := "overlays/odh"
-		if manifestConfig.SourcePath != "" {
-			defaultKustomizePath = manifestConfig.SourcePath
-		}
-		Path = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 	}
 
 	return nil
 }
 
+func (m *ModelRegistry) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentName: deploy.ManifestsPath(ComponentName, "overlays/odh", m.ManifestsOverride(nil)),
+	}
+}
+
 func (m *ModelRegistry) GetComponentName() string {
 	return ComponentName
 }
@@ -101,6 +100,7 @@ func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Clien
 	l := logf.FromContext(ctx)
 	enabled := m.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
+	manifestsPath := m.ManifestPaths(platform)[ComponentName]
 
 	if enabled {
 		// return error if ServiceMesh is not enabled, as it's a required feature
@@ -114,16 +114,17 @@ func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Clien
 
 		if m.DevFlags != nil {
 			// Download manifests and update paths
-			if err := m.OverrideManifests(ctx, platform); err != nil {
+			if err := m.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
+			manifestsPath = m.ManifestPaths(platform)[ComponentName]
 		}
 
 		extraParamsMap := map[string]string{
 			"DEFAULT_CERT": DefaultModelRegistryCert,
 		}
-		if err := deploy.ApplyParams(Path, nil, extraParamsMap); err != nil {
-			return fmt.Errorf("failed to update image from %s : %w", Path, err)
+		if err := deploy.ApplyParams(manifestsPath, nil, extraParamsMap); err != nil {
+			return fmt.Errorf("failed to update image from %s : %w", manifestsPath, err)
 		}
 
 		// Create model registries namespace
@@ -147,13 +148,13 @@ func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Clien
 	}
 
 	// Deploy ModelRegistry Operator
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path, dscispec.ApplicationsNamespace, m.GetComponentName(), enabled); err != nil {
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, manifestsPath, dscispec.ApplicationsNamespace, m.GetComponentName(), enabled); err != nil {
 		return err
 	}
 	l.Info("apply manifests done")
 
 	// Create additional model registry resources, componentEnabled=true because these extras are never deleted!
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path+"/extras", dscispec.ApplicationsNamespace, m.GetComponentName(), true); err != nil {
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, manifestsPath+"/extras", dscispec.ApplicationsNamespace, m.GetComponentName(), true); err != nil {
 		return err
 	}
 	l.Info("apply extra manifests done")
@@ -21,7 +21,6 @@ import (
 
 var (
 	ComponentName = "ray"
-	RayPath       = deploy.DefaultManifestPath + "/" + ComponentName + "/openshift"
 )
 
 // Verifies that Ray implements ComponentInterface.
@@ -33,37 +32,37 @@ type Ray struct {
 	components.Component `json:""`
 }
 
-func (r *Ray) Init(ctx context.Context, _ cluster.Platform) error {
+func (r *Ray) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
 	var imageParamMap = map[string]string{
 		"odh-kuberay-operator-controller-image": "RELATED_IMAGE_ODH_KUBERAY_OPERATOR_CONTROLLER_IMAGE",
 	}
-	if err := deploy.ApplyParams(RayPath, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", RayPath)
+	manifestsPath := r.ManifestPaths(platform)[ComponentName]
+	if err := deploy.ApplyParams(manifestsPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", manifestsPath)
 	}
 
 	return nil
 }
 
-func (r *Ray) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(r.DevFlags.Manifests) != 0 {
-		manifestConfig := r.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentName, manifestConfig); err != nil {
+func (r *Ray) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := r.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentName, *manifestConfig); err != nil {
 			return err
 		}
-		// If overlay is defined, update paths
-		defaultKustomizePath := "openshift"
-		if manifestConfig.SourcePath != "" {
-			defaultKustomizePath = manifestConfig.SourcePath
-		}
-		RayPath = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 	}
 
 	return nil
 }
 
+func (r *Ray) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentName: deploy.ManifestsPath(ComponentName, "openshift", r.ManifestsOverride(nil)),
+	}
+}
+
 func (r *Ray) GetComponentName() string {
 	return ComponentName
 }
@@ -73,21 +72,23 @@ func (r *Ray) ReconcileComponent(ctx context.Context, cli client.Client,
 	l := logf.FromContext(ctx)
 	enabled := r.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
+	manifestsPath := r.ManifestPaths(platform)[ComponentName]
 
 	if enabled {
 		if r.DevFlags != nil {
 			// Download manifests and update paths
-			if err := r.OverrideManifests(ctx, platform); err != nil {
+			if err := r.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
+			manifestsPath = r.ManifestPaths(platform)[ComponentName]
 		}
-		if err := deploy.ApplyParams(RayPath, nil, map[string]string{"namespace": dscispec.ApplicationsNamespace}); err != nil {
-			return fmt.Errorf("failed to update namespace from %s : %w", RayPath, err)
+		if err := deploy.ApplyParams(manifestsPath, nil, map[string]string{"namespace": dscispec.ApplicationsNamespace}); err != nil {
+			return fmt.Errorf("failed to update namespace from %s : %w", manifestsPath, err)
 		}
 	}
 	// Deploy Ray Operator
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, RayPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
-		return fmt.Errorf("failed to apply manifest from %s : %w", RayPath, err)
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, manifestsPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+		return fmt.Errorf("failed to apply manifest from %s : %w", manifestsPath, err)
 	}
 	l.Info("apply manifests done")
 
@@ -20,8 +20,7 @@ import (
 )
 
 var (
-	ComponentName        = "trainingoperator"
-	TrainingOperatorPath = deploy.DefaultManifestPath + "/" + ComponentName + "/rhoai"
+	ComponentName = "trainingoperator"
 )
 
 // Verifies that TrainingOperator implements ComponentInterface.
@@ -33,38 +32,38 @@ type TrainingOperator struct {
 	components.Component `json:""`
 }
 
-func (r *TrainingOperator) Init(ctx context.Context, _ cluster.Platform) error {
+func (r *TrainingOperator) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
 	var imageParamMap = map[string]string{
 		"odh-training-operator-controller-image": "RELATED_IMAGE_ODH_TRAINING_OPERATOR_IMAGE",
 	}
 
-	if err := deploy.ApplyParams(TrainingOperatorPath, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", TrainingOperatorPath)
+	manifestsPath := r.ManifestPaths(platform)[ComponentName]
+	if err := deploy.ApplyParams(manifestsPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", manifestsPath)
 	}
 
 	return nil
 }
 
-func (r *TrainingOperator) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(r.DevFlags.Manifests) != 0 {
-		manifestConfig := r.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentName, manifestConfig); err != nil {
+func (r *TrainingOperator) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := r.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentName, *manifestConfig); err != nil {
 			return err
 		}
-		// If overlay is defined, update paths
-		defaultKustomizePath := "rhoai"
-		if manifestConfig.SourcePath != "" {
-			defaultKustomizePath = manifestConfig.SourcePath
-		}
-		TrainingOperatorPath = filepath.Join(deploy.DefaultManifestPath, ComponentName, defaultKustomizePath)
 	}
 
 	return nil
 }
 
+func (r *TrainingOperator) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	return components.ManifestPaths{
+		ComponentName: deploy.ManifestsPath(ComponentName, "rhoai", r.ManifestsOverride(nil)),
+	}
+}
+
 func (r *TrainingOperator) GetComponentName() string {
 	return ComponentName
 }
@@ -74,17 +73,19 @@ func (r *TrainingOperator) ReconcileComponent(ctx context.Context, cli client.Cl
 	l := logf.FromContext(ctx)
 	enabled := r.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
+	manifestsPath := r.ManifestPaths(platform)[ComponentName]
 
 	if enabled {
 		if r.DevFlags != nil {
 			// Download manifests and update paths
-			if err := r.OverrideManifests(ctx, platform); err != nil {
+			if err := r.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
+			manifestsPath = r.ManifestPaths(platform)[ComponentName]
 		}
 	}
 	// Deploy Training Operator
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, TrainingOperatorPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, manifestsPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
 		return err
 	}
 	l.Info("apply manifests done")
@@ -21,10 +21,14 @@ import (
 var (
 	ComponentName     = "trustyai"
 	ComponentPathName = "trustyai-service-operator"
-	PathUpstream      = deploy.DefaultManifestPath + "/" + ComponentPathName + "/overlays/odh"
-	PathDownstream    = deploy.DefaultManifestPath + "/" + ComponentPathName + "/overlays/rhoai"
-	OverridePath      = ""
-	DefaultPath       = ""
+
+	// defaultPaths holds kustomize directories of the shipped manifests for each platform.
+	defaultPaths = map[cluster.Platform]string{
+		cluster.SelfManagedRhoai: "overlays/rhoai",
+		cluster.ManagedRhoai:     "overlays/rhoai",
+		cluster.OpenDataHub:      "overlays/odh",
+		cluster.Unknown:          "overlays/odh",
+	}
 )
 
 // Verifies that TrustyAI implements ComponentInterface.
@@ -39,41 +43,42 @@ type TrustyAI struct {
 func (t *TrustyAI) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
-	DefaultPath = map[cluster.Platform]string{
-		cluster.SelfManagedRhoai: PathDownstream,
-		cluster.ManagedRhoai:     PathDownstream,
-		cluster.OpenDataHub:      PathUpstream,
-		cluster.Unknown:          PathUpstream,
-	}[platform]
 	var imageParamMap = map[string]string{
 		"trustyaiServiceImage":  "RELATED_IMAGE_ODH_TRUSTYAI_SERVICE_IMAGE",
 		"trustyaiOperatorImage": "RELATED_IMAGE_ODH_TRUSTYAI_SERVICE_OPERATOR_IMAGE",
 	}
 
-	if err := deploy.ApplyParams(DefaultPath, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", DefaultPath)
+	entryPath := t.ManifestPaths(platform)[ComponentPathName]
+	if err := deploy.ApplyParams(entryPath, imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", entryPath)
 	}
 
 	return nil
 }
 
-func (t *TrustyAI) OverrideManifests(ctx context.Context, _ cluster.Platform) error {
-	// If devflags are set, update default manifests path
-	if len(t.DevFlags.Manifests) != 0 {
-		manifestConfig := t.DevFlags.Manifests[0]
-		if err := deploy.DownloadManifests(ctx, ComponentPathName, manifestConfig); err != nil {
+func (t *TrustyAI) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// If devflags are set, download manifests used by ManifestPaths
+	if manifestConfig := t.ManifestsOverride(nil); manifestConfig != nil {
+		if err := deploy.DownloadManifests(ctx, cli, ComponentPathName, *manifestConfig); err != nil {
 			return err
 		}
-		// If overlay is defined, update paths
-		defaultKustomizePath := "base"
-		if manifestConfig.SourcePath != "" {
-			defaultKustomizePath = manifestConfig.SourcePath
-		}
-		OverridePath = filepath.Join(deploy.DefaultManifestPath, ComponentPathName, defaultKustomizePath)
 	}
 	return nil
 }
 
+func (t *TrustyAI) ManifestPaths(platform cluster.Platform) components.ManifestPaths {
+	defaultPath := defaultPaths[platform]
+	// overridden manifests are deployed from base unless the overlay is defined
+	override := t.ManifestsOverride(nil)
+	if override != nil {
+		defaultPath = "base"
+	}
+
+	return components.ManifestPaths{
+		ComponentPathName: deploy.ManifestsPath(ComponentPathName, defaultPath, override),
+	}
+}
+
 func (t *TrustyAI) GetComponentName() string {
 	return ComponentName
 }
@@ -83,17 +88,15 @@ func (t *TrustyAI) ReconcileComponent(ctx context.Context, cli client.Client,
 	l := logf.FromContext(ctx)
 	enabled := t.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
-	entryPath := DefaultPath
+	entryPath := t.ManifestPaths(platform)[ComponentPathName]
 
 	if enabled {
 		if t.DevFlags != nil {
 			// Download manifests and update paths
-			if err := t.OverrideManifests(ctx, platform); err != nil {
+			if err := t.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
-			if OverridePath != "" {
-				entryPath = OverridePath
-			}
+			entryPath = t.ManifestPaths(platform)[ComponentPathName]
 		}
 	}
 	// Deploy TrustyAI Operator
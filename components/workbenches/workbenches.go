@@ -24,11 +24,9 @@ var (
 	ComponentName          = "workbenches"
 	DependentComponentName = "notebooks"
 	// manifests for nbc in ODH and RHOAI + downstream use it for imageparams.
-	notebookControllerPath = deploy.DefaultManifestPath + "/odh-notebook-controller/odh-notebook-controller/base"
+	notebookControllerName = "odh-notebook-controller/odh-notebook-controller"
 	// manifests for ODH nbc + downstream use it for imageparams.
-	kfnotebookControllerPath = deploy.DefaultManifestPath + "/odh-notebook-controller/kf-notebook-controller/overlays/openshift"
-	// notebook image manifests.
-	notebookImagesPath = deploy.DefaultManifestPath + "/notebooks/overlays/additional"
+	kfNotebookControllerName = "odh-notebook-controller/kf-notebook-controller"
 )
 
 // Verifies that Workbench implements ComponentInterface.
@@ -40,7 +38,7 @@ type Workbenches struct {
 	components.Component `json:""`
 }
 
-func (w *Workbenches) Init(ctx context.Context, _ cluster.Platform) error {
+func (w *Workbenches) Init(ctx context.Context, platform cluster.Platform) error {
 	log := logf.FromContext(ctx).WithName(ComponentName)
 
 	var imageParamMap = map[string]string{
@@ -48,64 +46,59 @@ func (w *Workbenches) Init(ctx context.Context, _ cluster.Platform) error {
 		"odh-kf-notebook-controller-image": "RELATED_IMAGE_ODH_KF_NOTEBOOK_CONTROLLER_IMAGE",
 	}
 
+	paths := w.ManifestPaths(platform)
+
 	// for kf-notebook-controller image
-	if err := deploy.ApplyParams(notebookControllerPath, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", notebookControllerPath)
+	if err := deploy.ApplyParams(paths[notebookControllerName], imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", paths[notebookControllerName])
 	}
 	// for odh-notebook-controller image
-	if err := deploy.ApplyParams(kfnotebookControllerPath, imageParamMap); err != nil {
-		log.Error(err, "failed to update image", "path", kfnotebookControllerPath)
+	if err := deploy.ApplyParams(paths[kfNotebookControllerName], imageParamMap); err != nil {
+		log.Error(err, "failed to update image", "path", paths[kfNotebookControllerName])
 	}
 
 	return nil
 }
 
-func (w *Workbenches) OverrideManifests(ctx context.Context, platform cluster.Platform) error {
-	// Download manifests if defined by devflags
-	// Go through each manifest and set the overlays if defined
+func (w *Workbenches) OverrideManifests(ctx context.Context, cli client.Client, _ cluster.Platform) error {
+	// Download manifests if defined by devflags, ManifestPaths picks the overlays
 	// first on odh-notebook-controller and kf-notebook-controller last to notebook-images
-	for _, subcomponent := range w.DevFlags.Manifests {
-		if strings.Contains(subcomponent.ContextDir, "components/odh-notebook-controller") {
-			// Download subcomponent
-			if err := deploy.DownloadManifests(ctx, "odh-notebook-controller/odh-notebook-controller", subcomponent); err != nil {
-				return err
-			}
-			// If overlay is defined, update paths
-			defaultKustomizePathNbc := "base"
-			if subcomponent.SourcePath != "" {
-				defaultKustomizePathNbc = subcomponent.SourcePath
-			}
-			notebookControllerPath = filepath.Join(deploy.DefaultManifestPath, "odh-notebook-controller/odh-notebook-controller", defaultKustomizePathNbc)
-		}
-
-		if strings.Contains(subcomponent.ContextDir, "components/notebook-controller") {
-			// Download subcomponent
-			if err := deploy.DownloadManifests(ctx, "odh-notebook-controller/kf-notebook-controller", subcomponent); err != nil {
-				return err
-			}
-			// If overlay is defined, update paths
-			defaultKustomizePathKfNbc := "overlays/openshift"
-			if subcomponent.SourcePath != "" {
-				defaultKustomizePathKfNbc = subcomponent.SourcePath
-			}
-			kfnotebookControllerPath = filepath.Join(deploy.DefaultManifestPath, "odh-notebook-controller/kf-notebook-controller", defaultKustomizePathKfNbc)
-		}
-		if strings.Contains(subcomponent.URI, DependentComponentName) {
-			// Download subcomponent
-			if err := deploy.DownloadManifests(ctx, DependentComponentName, subcomponent); err != nil {
+	overrides := w.manifestsOverrides()
+	for _, name := range []string{notebookControllerName, kfNotebookControllerName, DependentComponentName} {
+		if subcomponent := overrides[name]; subcomponent != nil {
+			if err := deploy.DownloadManifests(ctx, cli, name, *subcomponent); err != nil {
 				return err
 			}
-			// If overlay is defined, update paths
-			defaultKustomizePath := "overlays/additional"
-			if subcomponent.SourcePath != "" {
-				defaultKustomizePath = subcomponent.SourcePath
-			}
-			notebookImagesPath = filepath.Join(deploy.DefaultManifestPath, DependentComponentName, defaultKustomizePath)
 		}
 	}
 	return nil
 }
 
+func (w *Workbenches) ManifestPaths(_ cluster.Platform) components.ManifestPaths {
+	overrides := w.manifestsOverrides()
+
+	return components.ManifestPaths{
+		notebookControllerName:   deploy.ManifestsPath(notebookControllerName, "base", overrides[notebookControllerName]),
+		kfNotebookControllerName: deploy.ManifestsPath(kfNotebookControllerName, "overlays/openshift", overrides[kfNotebookControllerName]),
+		// notebook image manifests.
+		DependentComponentName: deploy.ManifestsPath(DependentComponentName, "overlays/additional", overrides[DependentComponentName]),
+	}
+}
+
+// manifestsOverrides returns devflags manifests of the subcomponents keyed by their manifests directory. Notebook
+// controllers are recognized by their contextDir, notebook images by their URI.
+func (w *Workbenches) manifestsOverrides() map[string]*components.ManifestsConfig {
+	return map[string]*components.ManifestsConfig{
+		notebookControllerName: w.ManifestsOverride(func(manifestsConfig components.ManifestsConfig) bool {
+			return strings.Contains(manifestsConfig.ContextDir, "components/odh-notebook-controller")
+		}),
+		kfNotebookControllerName: w.ManifestsOverride(func(manifestsConfig components.ManifestsConfig) bool {
+			return strings.Contains(manifestsConfig.ContextDir, "components/notebook-controller")
+		}),
+		DependentComponentName: w.ManifestsOverride(components.URIContains(DependentComponentName)),
+	}
+}
+
 func (w *Workbenches) GetComponentName() string {
 	return ComponentName
 }
@@ -117,12 +110,14 @@ func (w *Workbenches) ReconcileComponent(ctx context.Context, cli client.Client,
 	// Create rhods-notebooks namespace in managed platforms
 	enabled := w.GetManagementState() == operatorv1.Managed
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
+	paths := w.ManifestPaths(platform)
 	if enabled {
 		if w.DevFlags != nil {
 			// Download manifests and update paths
-			if err := w.OverrideManifests(ctx, platform); err != nil {
+			if err := w.OverrideManifests(ctx, cli, platform); err != nil {
 				return err
 			}
+			paths = w.ManifestPaths(platform)
 		}
 		if platform == cluster.SelfManagedRhoai || platform == cluster.ManagedRhoai {
 			// Intentionally leaving the ownership unset for this namespace.
@@ -140,28 +135,28 @@ func (w *Workbenches) ReconcileComponent(ctx context.Context, cli client.Client,
 	}
 
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner,
-		notebookControllerPath,
+		paths[notebookControllerName],
 		dscispec.ApplicationsNamespace,
 		ComponentName, enabled); err != nil {
-		return fmt.Errorf("failed to apply manifests %s: %w", notebookControllerPath, err)
+		return fmt.Errorf("failed to apply manifests %s: %w", paths[notebookControllerName], err)
 	}
-	l.WithValues("Path", notebookControllerPath).Info("apply manifests done notebook controller done")
+	l.WithValues("Path", paths[notebookControllerName]).Info("apply manifests done notebook controller done")
 
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner,
-		kfnotebookControllerPath,
+		paths[kfNotebookControllerName],
 		dscispec.ApplicationsNamespace,
 		ComponentName, enabled); err != nil {
-		return fmt.Errorf("failed to apply manifests %s: %w", kfnotebookControllerPath, err)
+		return fmt.Errorf("failed to apply manifests %s: %w", paths[kfNotebookControllerName], err)
 	}
-	l.WithValues("Path", kfnotebookControllerPath).Info("apply manifests done kf-notebook controller done")
+	l.WithValues("Path", paths[kfNotebookControllerName]).Info("apply manifests done kf-notebook controller done")
 
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner,
-		notebookImagesPath,
+		paths[DependentComponentName],
 		dscispec.ApplicationsNamespace,
 		ComponentName, enabled); err != nil {
 		return err
 	}
-	l.WithValues("Path", notebookImagesPath).Info("apply manifests done notebook image done")
+	l.WithValues("Path", paths[DependentComponentName]).Info("apply manifests done notebook image done")
 
 	// Wait for deployment available
 	if enabled {
@@ -0,0 +1,239 @@
+package datasciencecluster_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/datasciencecluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// concurrency tracks how many components are reconciled at the same time.
+type concurrency struct {
+	mu      sync.Mutex
+	running map[string]bool
+	peak    int
+	overlap [][]string
+}
+
+func (c *concurrency) enter(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	running := make([]string, 0, len(c.running)+1)
+	for other := range c.running {
+		running = append(running, other)
+	}
+	c.running[name] = true
+	c.overlap = append(c.overlap, append(running, name))
+	c.peak = max(c.peak, len(c.running))
+}
+
+func (c *concurrency) leave(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.running, name)
+}
+
+type testComponent struct {
+	components.Component
+	name      string
+	manifests []string
+	dir       string
+	err       error
+	tracker   *concurrency
+}
+
+func (t *testComponent) Init(context.Context, cluster.Platform) error {
+	return nil
+}
+
+func (t *testComponent) ReconcileComponent(context.Context, client.Client, metav1.Object, *dsciv1.DSCInitializationSpec, cluster.Platform, bool) error {
+	t.tracker.enter(t.name)
+	defer t.tracker.leave(t.name)
+
+	time.Sleep(50 * time.Millisecond)
+
+	return t.err
+}
+
+func (t *testComponent) Cleanup(context.Context, client.Client, metav1.Object, *dsciv1.DSCInitializationSpec) error {
+	return nil
+}
+
+func (t *testComponent) GetComponentName() string {
+	return t.name
+}
+
+func (t *testComponent) OverrideManifests(context.Context, client.Client, cluster.Platform) error {
+	return nil
+}
+
+func (t *testComponent) ManifestPaths(cluster.Platform) components.ManifestPaths {
+	paths := components.ManifestPaths{}
+	for _, manifests := range t.manifests {
+		paths[manifests] = filepath.Join(t.dir, manifests)
+	}
+
+	return paths
+}
+
+func (t *testComponent) UpdatePrometheusConfig(client.Client, logr.Logger, bool, string) error {
+	return nil
+}
+
+var _ = Describe("Reconciling components", func() {
+
+	var (
+		cli     client.Client
+		dsc     *dscv1.DataScienceCluster
+		tracker *concurrency
+	)
+
+	reconciler := func(maxConcurrent int) *datasciencecluster.DataScienceClusterReconciler {
+		return &datasciencecluster.DataScienceClusterReconciler{
+			Client:   cli,
+			Recorder: record.NewFakeRecorder(100),
+			DataScienceCluster: &datasciencecluster.DataScienceClusterConfig{
+				DSCISpec:                         &dsciv1.DSCInitializationSpec{ApplicationsNamespace: "app-ns"},
+				MaxConcurrentComponentReconciles: maxConcurrent,
+			},
+		}
+	}
+
+	component := func(name string, manifests ...string) *testComponent {
+		return &testComponent{
+			Component: components.Component{ManagementState: operatorv1.Managed},
+			name:      name,
+			manifests: append([]string{name}, manifests...),
+			dir:       "/opt/manifests",
+			tracker:   tracker,
+		}
+	}
+
+	BeforeEach(func() {
+		testScheme := runtime.NewScheme()
+		Expect(dscv1.AddToScheme(testScheme)).To(Succeed())
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+
+		dsc = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		cli = fake.NewClientBuilder().
+			WithScheme(testScheme).
+			WithObjects(dsc).
+			WithStatusSubresource(dsc).
+			Build()
+		tracker = &concurrency{running: map[string]bool{}}
+	})
+
+	It("should reconcile at most the configured number of components at the same time", func(ctx context.Context) {
+		// given
+		allComponents := []components.ComponentInterface{
+			component("first"), component("second"), component("third"), component("fourth"), component("fifth"),
+		}
+
+		// when
+		Expect(reconciler(2).ReconcileComponents(ctx, dsc, cluster.OpenDataHub, allComponents)).To(Succeed())
+
+		// then
+		Expect(tracker.peak).To(Equal(2))
+	})
+
+	It("should reconcile components sharing manifests one after another", func(ctx context.Context) {
+		// given
+		allComponents := []components.ComponentInterface{
+			component("kserve", "odh-model-controller"),
+			component("modelmeshserving", "odh-model-controller"),
+			component("dashboard"),
+		}
+
+		// when
+		Expect(reconciler(3).ReconcileComponents(ctx, dsc, cluster.OpenDataHub, allComponents)).To(Succeed())
+
+		// then
+		Expect(tracker.overlap).ToNot(ContainElement(ContainElements("kserve", "modelmeshserving")))
+		Expect(tracker.peak).To(Equal(2))
+	})
+
+	It("should merge errors and statuses of all components", func(ctx context.Context) {
+		// given
+		failing := component("failing")
+		failing.err = errors.New("deployment failed")
+		allComponents := []components.ComponentInterface{component("first"), failing, component("second")}
+
+		// when
+		err := reconciler(3).ReconcileComponents(ctx, dsc, cluster.OpenDataHub, allComponents)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("deployment failed")))
+
+		reconciled := &dscv1.DataScienceCluster{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(dsc), reconciled)).To(Succeed())
+		Expect(reconciled.Status.InstalledComponents).To(Equal(map[string]bool{"first": true, "second": true}))
+		Expect(reconciled.Status.Conditions).To(ContainElements(
+			And(
+				HaveField("Type", BeEquivalentTo("firstReady")),
+				HaveField("Reason", Equal(status.ReconcileCompleted)),
+			),
+			And(
+				HaveField("Type", BeEquivalentTo("secondReady")),
+				HaveField("Reason", Equal(status.ReconcileCompleted)),
+			),
+			And(
+				HaveField("Type", BeEquivalentTo("failingReady")),
+				HaveField("Reason", Equal(status.ReconcileFailed)),
+				HaveField("Message", ContainSubstring("deployment failed")),
+			),
+		))
+	})
+
+	It("should plan manifests of components without reconciling them", func(ctx context.Context) {
+		// given
+		manifestsDir := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(manifestsDir, "planned"), os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(manifestsDir, "planned", "kustomization.yaml"), []byte("resources:\n- cm.yaml\n"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(manifestsDir, "planned", "cm.yaml"),
+			[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: planned-config\n"), 0o600)).To(Succeed())
+
+		planned := component("planned")
+		planned.dir = manifestsDir
+		dsc.Annotations = map[string]string{annotations.ManifestsPlan: "requested"}
+
+		// when
+		Expect(reconciler(1).ReportManifestsPlan(ctx, dsc, cluster.OpenDataHub, []components.ComponentInterface{planned})).To(Succeed())
+
+		// then
+		Expect(tracker.overlap).To(BeEmpty(), "component should not be reconciled")
+
+		err := cli.Get(ctx, client.ObjectKey{Namespace: "app-ns", Name: "planned-config"}, &corev1.ConfigMap{})
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+
+		report := &corev1.ConfigMap{}
+		Expect(cli.Get(ctx, client.ObjectKey{Namespace: "app-ns", Name: "default-dsc-manifests-plan"}, report)).To(Succeed())
+		Expect(report.Data["plan"]).To(ContainSubstring("planned-config"))
+		Expect(report.Data["plan"]).To(ContainSubstring("Create"))
+		Expect(report.Data["plan"]).ToNot(ContainSubstring("errors"))
+	})
+})
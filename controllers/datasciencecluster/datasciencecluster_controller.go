@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -57,6 +58,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/modelregistry"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 	annotations "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
@@ -74,6 +76,8 @@ type DataScienceClusterReconciler struct {
 // DataScienceClusterConfig passing Spec of DSCI for reconcile DataScienceCluster.
 type DataScienceClusterConfig struct {
 	DSCISpec *dsciv1.DSCInitializationSpec
+	// MaxConcurrentComponentReconciles limits how many components are reconciled at the same time, defaults to 1.
+	MaxConcurrentComponentReconciles int
 }
 
 const (
@@ -237,15 +241,23 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 	}
 
-	// Initialize error list, instead of returning errors after every component is deployed
-	var componentErrors *multierror.Error
+	// Report changes of component manifests before they are deployed, when requested
+	if instance.GetAnnotations()[annotations.ManifestsPlan] != "" {
+		if err := r.reportManifestsPlan(ctx, instance, platform, allComponents, currentOperatorRelease); err != nil {
+			_ = r.reportError(ctx, err, instance, "failed to plan changes of component manifests")
 
-	for _, component := range allComponents {
-		if instance, err = r.reconcileSubComponent(ctx, instance, platform, component); err != nil {
-			componentErrors = multierror.Append(componentErrors, err)
+			return ctrl.Result{}, err
+		}
+		if instance.GetAnnotations()[annotations.ManifestsPlanOnly] == "true" {
+			log.Info("Deployment of component manifests is held by annotation", "annotation", annotations.ManifestsPlanOnly)
+
+			return ctrl.Result{}, nil
 		}
 	}
 
+	// Errors are collected instead of returning after every component is deployed
+	componentErrors := r.reconcileComponents(ctx, instance, platform, allComponents)
+
 	// Process errors for components
 	if componentErrors != nil {
 		log.Info("DataScienceCluster Deployment Incomplete.")
@@ -311,8 +323,13 @@ func (r *DataScienceClusterReconciler) reconcileSubComponent(ctx context.Context
 		}
 	}
 	// Reconcile component
-	componentCtx := newComponentContext(ctx, log, componentName)
+	componentCtx, conflicts := cluster.WithConflictRecorder(newComponentContext(ctx, log, componentName))
+	componentCtx, inventory := deploy.WithInventory(componentCtx, instance, r.DataScienceCluster.DSCISpec.ApplicationsNamespace)
 	err := component.ReconcileComponent(componentCtx, r.Client, instance, r.DataScienceCluster.DSCISpec, platform, installedComponentValue)
+	if err == nil && enabled {
+		// remove resources dropped from the manifests, only after all of them were deployed successfully
+		err = inventory.Prune(componentCtx, r.Client)
+	}
 
 	// TODO: replace this hack with a full refactor of component status in the future
 
@@ -339,7 +356,12 @@ func (r *DataScienceClusterReconciler) reconcileSubComponent(ctx context.Context
 		}
 		saved.Status.InstalledComponents[componentName] = enabled
 		if enabled {
-			status.SetComponentCondition(&saved.Status.Conditions, componentName, status.ReconcileCompleted, "Component reconciled successfully", corev1.ConditionTrue)
+			if conflictsErr := conflicts.Err(); conflictsErr != nil {
+				status.SetComponentCondition(&saved.Status.Conditions, componentName, status.ReconcileCompletedWithConflicts,
+					fmt.Sprintf("Component reconciled with %v", conflictsErr), corev1.ConditionTrue)
+			} else {
+				status.SetComponentCondition(&saved.Status.Conditions, componentName, status.ReconcileCompleted, "Component reconciled successfully", corev1.ConditionTrue)
+			}
 		} else {
 			status.RemoveComponentCondition(&saved.Status.Conditions, componentName)
 		}
@@ -362,6 +384,94 @@ func (r *DataScienceClusterReconciler) reconcileSubComponent(ctx context.Context
 	return instance, nil
 }
 
+// reconcileComponents reconciles components concurrently, up to MaxConcurrentComponentReconciles at a time, so
+// a component waiting for its deployments to become available does not delay the others. Components sharing manifests
+// are reconciled one after another (see componentGroups). Each component reconciles its own copy of the instance,
+// and its conditions are merged into the status by status.UpdateWithRetry.
+func (r *DataScienceClusterReconciler) reconcileComponents(ctx context.Context, instance *dscv1.DataScienceCluster,
+	platform cluster.Platform, allComponents []components.ComponentInterface,
+) error {
+	workers := max(r.DataScienceCluster.MaxConcurrentComponentReconciles, 1)
+
+	var (
+		wg              sync.WaitGroup
+		mu              sync.Mutex
+		componentErrors *multierror.Error
+	)
+	slots := make(chan struct{}, workers)
+	for _, group := range componentGroups(platform, allComponents) {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(group []components.ComponentInterface) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			for _, component := range group {
+				if _, err := r.reconcileSubComponent(ctx, instance.DeepCopy(), platform, component); err != nil {
+					mu.Lock()
+					componentErrors = multierror.Append(componentErrors, err)
+					mu.Unlock()
+				}
+			}
+		}(group)
+	}
+	wg.Wait()
+
+	return componentErrors.ErrorOrNil()
+}
+
+// componentGroups partitions components into groups which are reconciled one after another, keeping their order.
+// Components deploying manifests of the same (dependent) component, e.g. odh-model-controller of kserve and
+// modelmeshserving, end up in the same group, so they do not apply and label the same resources at the same time.
+func componentGroups(platform cluster.Platform, allComponents []components.ComponentInterface) [][]components.ComponentInterface {
+	var groups [][]components.ComponentInterface
+	groupOf := map[string]int{}
+	for _, component := range allComponents {
+		paths := component.ManifestPaths(platform)
+		group := -1
+		for manifests := range paths {
+			shared, found := groupOf[manifests]
+			if !found || shared == group {
+				continue
+			}
+			if group < 0 {
+				group = shared
+
+				continue
+			}
+			// the component shares manifests with two groups, so they are merged into the earlier one
+			from, into := max(group, shared), min(group, shared)
+			groups[into] = append(groups[into], groups[from]...)
+			groups[from] = nil
+			for name, g := range groupOf {
+				if g == from {
+					groupOf[name] = into
+				}
+			}
+			group = into
+		}
+		if group < 0 {
+			group = len(groups)
+			groups = append(groups, nil)
+		}
+		groups[group] = append(groups[group], component)
+		for manifests := range paths {
+			groupOf[manifests] = group
+		}
+	}
+
+	nonEmpty := groups[:0]
+	for _, group := range groups {
+		if len(group) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+
+	return nonEmpty
+}
+
 func newComponentContext(ctx context.Context, log logr.Logger, componentName string) context.Context {
 	return logf.IntoContext(ctx, log.WithName(componentName).WithValues("component", componentName))
 }
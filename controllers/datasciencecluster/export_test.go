@@ -0,0 +1,23 @@
+package datasciencecluster
+
+import (
+	"context"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+// ReconcileComponents exposes reconcileComponents to the tests.
+func (r *DataScienceClusterReconciler) ReconcileComponents(ctx context.Context, instance *dscv1.DataScienceCluster,
+	platform cluster.Platform, allComponents []components.ComponentInterface,
+) error {
+	return r.reconcileComponents(ctx, instance, platform, allComponents)
+}
+
+// ReportManifestsPlan exposes reportManifestsPlan to the tests.
+func (r *DataScienceClusterReconciler) ReportManifestsPlan(ctx context.Context, instance *dscv1.DataScienceCluster,
+	platform cluster.Platform, allComponents []components.ComponentInterface,
+) error {
+	return r.reportManifestsPlan(ctx, instance, platform, allComponents, cluster.Release{})
+}
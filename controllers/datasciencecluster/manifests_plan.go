@@ -0,0 +1,141 @@
+package datasciencecluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	featureresource "github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+const (
+	manifestsPlanReleaseKey = "release"
+	manifestsPlanKey        = "plan"
+)
+
+// manifestsPlan is the report stored in the <dsc-name>-manifests-plan ConfigMap.
+type manifestsPlan struct {
+	// Components lists changes of resources grouped by the app.opendatahub.io/<component> label.
+	Components map[string][]featureresource.Change `json:"components,omitempty"`
+	// Errors lists components which could not be planned, e.g. because they depend on resources which are not created yet.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// reportManifestsPlan renders manifests of all components and stores changes which deploying them would introduce
+// in a ConfigMap in the applications namespace. The report is only generated again when the value of the annotation
+// requesting it or the operator release changes.
+//
+// Components are not reconciled, so planning neither downloads manifests, sets their parameters nor waits for
+// deployments. Manifests are rendered from the paths the components resolve (see components.ManifestPaths),
+// with the parameters set when they were initialized and last reconciled, and compared using server-side dry-run.
+// Manifests deployed by components only under some conditions, e.g. monitoring configuration of managed clusters,
+// are not part of the plan.
+func (r *DataScienceClusterReconciler) reportManifestsPlan(ctx context.Context, instance *dscv1.DataScienceCluster,
+	platform cluster.Platform, allComponents []components.ComponentInterface, release cluster.Release,
+) error {
+	log := logf.FromContext(ctx)
+	requested := instance.GetAnnotations()[annotations.ManifestsPlan]
+	releaseVersion := release.Version.String()
+
+	report := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name + "-manifests-plan",
+			Namespace: r.DataScienceCluster.DSCISpec.ApplicationsNamespace,
+		},
+	}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(report), report); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed getting manifests plan %s/%s: %w", report.Namespace, report.Name, err)
+	}
+	if report.GetAnnotations()[annotations.ManifestsPlan] == requested && report.Data[manifestsPlanReleaseKey] == releaseVersion {
+		return nil
+	}
+
+	log.Info("Planning changes of component manifests", "request", requested, "release", releaseVersion)
+
+	// every write is sent as dry-run by the client of the plan, manifests are additionally compared with the live objects
+	planCtx, plan := deploy.WithPlan(ctx)
+	planned := manifestsPlan{}
+	for _, component := range allComponents {
+		componentName := component.GetComponentName()
+		componentCtx, _ := cluster.WithConflictRecorder(newComponentContext(planCtx, log, componentName))
+		componentCtx, inventory := deploy.WithInventory(componentCtx, instance, r.DataScienceCluster.DSCISpec.ApplicationsNamespace)
+
+		err := r.planComponentManifests(componentCtx, instance, platform, component)
+		if err == nil && component.GetManagementState() == operatorv1.Managed {
+			err = inventory.Prune(componentCtx, r.Client)
+		}
+		if err != nil {
+			if planned.Errors == nil {
+				planned.Errors = map[string]string{}
+			}
+			planned.Errors[componentName] = err.Error()
+		}
+	}
+	planned.Components = plan.Changes()
+
+	content, err := yaml.Marshal(planned)
+	if err != nil {
+		return err
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, report, func() error {
+		if report.Annotations == nil {
+			report.Annotations = map[string]string{}
+		}
+		report.Annotations[annotations.ManifestsPlan] = requested
+		report.Data = map[string]string{
+			manifestsPlanReleaseKey: releaseVersion,
+			manifestsPlanKey:        string(content),
+		}
+
+		return controllerutil.SetControllerReference(instance, report, r.Client.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed storing manifests plan %s/%s: %w", report.Namespace, report.Name, err)
+	}
+
+	return nil
+}
+
+// planComponentManifests passes manifests of the component through the same steps as when they are deployed,
+// which the plan carried by the context switches to dry-run. Paths are planned in a stable order.
+func (r *DataScienceClusterReconciler) planComponentManifests(ctx context.Context, instance *dscv1.DataScienceCluster,
+	platform cluster.Platform, component components.ComponentInterface,
+) error {
+	label := component.GetComponentName()
+	if labeler, customLabel := component.(components.ManifestsLabeler); customLabel {
+		label = labeler.ManifestsLabel(platform)
+	}
+	enabled := component.GetManagementState() == operatorv1.Managed
+
+	paths := component.ManifestPaths(platform)
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var multiErr *multierror.Error
+	for _, name := range names {
+		if err := deploy.DeployManifestsFromPath(ctx, r.Client, instance, paths[name],
+			r.DataScienceCluster.DSCISpec.ApplicationsNamespace, label, enabled); err != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("failed planning manifests of %s: %w", name, err))
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
@@ -42,8 +42,16 @@ func createCapabilityReporter(cli client.Client, object *dsciv1.DSCInitializatio
 					actualCondition.Message = err.Error()
 					actualCondition.Reason = status.CapabilityFailed
 					var missingOperatorErr *feature.MissingOperatorError
-					if errors.As(err, &missingOperatorErr) {
+					// warnings are checked first, as they can carry errors of optional checks, e.g. missing operator
+					if feature.IsWarning(err) {
+						// capability is configured, but some of the optional checks failed
+						actualCondition.Status = corev1.ConditionTrue
+						actualCondition.Reason = status.CapabilityDegraded
+					} else if errors.As(err, &missingOperatorErr) {
 						actualCondition.Reason = status.MissingOperatorReason
+					} else if _, notReady := feature.IsNotReady(err); notReady {
+						actualCondition.Status = corev1.ConditionUnknown
+						actualCondition.Reason = status.CapabilityProgressing
 					}
 				}
 				conditionsv1.SetStatusCondition(&saved.Status.Conditions, *actualCondition)
@@ -0,0 +1,69 @@
+package dscinitialization_test
+
+import (
+	"context"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ofapi "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	dscictrl "github.com/opendatahub-io/opendatahub-operator/v2/controllers/dscinitialization"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reporting authorization capability", func() {
+
+	It("should report missing Authorino operator as degraded capability", func(ctx context.Context) {
+		// given
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(dsciv1.AddToScheme(scheme))
+		utilruntime.Must(featurev1.AddToScheme(scheme))
+		utilruntime.Must(ofapi.AddToScheme(scheme))
+
+		dsci := &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci", UID: "dsci-uid"},
+			Spec: dsciv1.DSCInitializationSpec{
+				ApplicationsNamespace: "test-application-ns",
+				ServiceMesh: &infrav1.ServiceMeshSpec{
+					ManagementState: operatorv1.Managed,
+					ControlPlane:    infrav1.ControlPlaneSpec{Name: "data-science-smcp", Namespace: "istio-system"},
+				},
+			},
+		}
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dsci).
+			WithStatusSubresource(dsci).
+			Build()
+		reconciler := &dscictrl.DSCInitializationReconciler{Client: cli}
+
+		// when
+		authzCapability, err := reconciler.AuthorizationCapability(ctx, dsci,
+			dscictrl.AuthorizationCondition(status.ConfiguredReason, "Service Mesh Authorization configured"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authzCapability.Apply(ctx, cli)).To(Succeed())
+
+		// then
+		reported := &dsciv1.DSCInitialization{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(dsci), reported)).To(Succeed())
+		condition := conditionsv1.FindStatusCondition(reported.Status.Conditions, status.CapabilityServiceMeshAuthorization)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(status.CapabilityDegraded))
+		Expect(condition.Message).To(ContainSubstring("authorino-operator"))
+	})
+})
@@ -48,6 +48,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/trustedcabundle"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
@@ -283,23 +284,34 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 		// Apply Service Mesh configurations
 		if errServiceMesh := r.configureServiceMesh(ctx, instance); errServiceMesh != nil {
+			if requeueAfter, notReady := feature.IsNotReady(errServiceMesh); notReady {
+				// reconciliation is completed, capabilities report their progress in their own conditions
+				r.completeReconcile(ctx, instance, status.PhaseNotReady)
+
+				return reconcile.Result{RequeueAfter: requeueAfter}, nil
+			}
+
 			return reconcile.Result{}, errServiceMesh
 		}
 
 		// Finish reconciling
-		_, err = status.UpdateWithRetry[*dsciv1.DSCInitialization](ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
-			status.SetCompleteCondition(&saved.Status.Conditions, status.ReconcileCompleted, status.ReconcileCompletedMessage)
-			saved.Status.Phase = status.PhaseReady
-		})
-		if err != nil {
-			log.Error(err, "failed to update DSCInitialization status after successfully completed reconciliation")
-			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DSCInitializationReconcileError", "Failed to update DSCInitialization status")
-		}
+		r.completeReconcile(ctx, instance, status.PhaseReady)
 
 		return ctrl.Result{}, nil
 	}
 }
 
+func (r *DSCInitializationReconciler) completeReconcile(ctx context.Context, instance *dsciv1.DSCInitialization, phase string) {
+	_, err := status.UpdateWithRetry[*dsciv1.DSCInitialization](ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+		status.SetCompleteCondition(&saved.Status.Conditions, status.ReconcileCompleted, status.ReconcileCompletedMessage)
+		saved.Status.Phase = phase
+	})
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to update DSCInitialization status after successfully completed reconciliation")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DSCInitializationReconcileError", "Failed to update DSCInitialization status")
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DSCInitializationReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
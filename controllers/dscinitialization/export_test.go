@@ -0,0 +1,20 @@
+package dscinitialization
+
+import (
+	"context"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+)
+
+// AuthorizationCondition exposes authorizationCondition to the tests.
+var AuthorizationCondition = authorizationCondition
+
+// AuthorizationCapability exposes authorizationCapability to the tests.
+func (r *DSCInitializationReconciler) AuthorizationCapability(ctx context.Context, instance *dsciv1.DSCInitialization,
+	condition *conditionsv1.Condition,
+) (*feature.HandlerWithReporter[*dsciv1.DSCInitialization], error) {
+	return r.authorizationCapability(ctx, instance, condition)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path"
 
+	"github.com/hashicorp/go-multierror"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -41,8 +42,16 @@ func (r *DSCInitializationReconciler) configureServiceMesh(ctx context.Context,
 		}
 		capabilities = append(capabilities, authzCapability)
 
+		// capabilities which are not ready yet do not hold back the remaining ones
+		var notReadyErrors *multierror.Error
 		for _, capability := range capabilities {
 			capabilityErr := capability.Apply(ctx, r.Client)
+			if _, notReady := feature.IsNotReady(capabilityErr); notReady {
+				log.Info("service mesh resources are not ready yet", "reason", capabilityErr.Error())
+				notReadyErrors = multierror.Append(notReadyErrors, capabilityErr)
+
+				continue
+			}
 			if capabilityErr != nil {
 				log.Error(capabilityErr, "failed applying service mesh resources")
 				r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DSCInitializationReconcileError", "failed applying service mesh resources")
@@ -50,6 +59,8 @@ func (r *DSCInitializationReconciler) configureServiceMesh(ctx context.Context,
 			}
 		}
 
+		return notReadyErrors.ErrorOrNil()
+
 	case operatorv1.Unmanaged:
 		log.Info("ServiceMesh CR is not configured by the operator, we won't do anything")
 	case operatorv1.Removed:
@@ -106,24 +117,8 @@ func (r *DSCInitializationReconciler) authorizationCapability(ctx context.Contex
 		return nil, fmt.Errorf("failed to list subscriptions %w", err)
 	}
 
-	if !authorinoInstalled {
-		authzMissingOperatorCondition := &conditionsv1.Condition{
-			Type:    status.CapabilityServiceMeshAuthorization,
-			Status:  corev1.ConditionFalse,
-			Reason:  status.MissingOperatorReason,
-			Message: "Authorino operator is not installed on the cluster, skipping authorization capability",
-		}
-
-		return feature.NewHandlerWithReporter(
-			// EmptyFeaturesHandler acts as all the authorization features are disabled (calling Apply/Delete has no actual effect on the cluster)
-			// but it's going to be reported as CapabilityServiceMeshAuthorization/MissingOperator condition/reason
-			feature.EmptyFeaturesHandler,
-			createCapabilityReporter(r.Client, instance, authzMissingOperatorCondition),
-		), nil
-	}
-
 	return feature.NewHandlerWithReporter(
-		feature.ClusterFeaturesHandler(instance, r.authorizationFeatures(instance)),
+		feature.ClusterFeaturesHandler(instance, r.authorizationFeatures(instance, authorinoInstalled)),
 		createCapabilityReporter(r.Client, instance, condition),
 	), nil
 }
@@ -138,6 +133,7 @@ func (r *DSCInitializationReconciler) serviceMeshCapabilityFeatures(instance *ds
 
 		return registry.Add(
 			feature.Define("mesh-control-plane-creation").
+				NonBlocking().
 				Manifests(
 					manifest.Location(Templates.Location).
 						Include(
@@ -153,7 +149,9 @@ func (r *DSCInitializationReconciler) serviceMeshCapabilityFeatures(instance *ds
 					feature.WaitForPodsToBeReady(controlPlaneSpec.Namespace),
 				),
 			feature.Define("mesh-metrics-collection").
+				NonBlocking().
 				EnabledWhen(meshMetricsCollection).
+				DependsOn("mesh-control-plane-creation").
 				Manifests(
 					manifest.Location(Templates.Location).
 						Include(
@@ -178,12 +176,23 @@ func (r *DSCInitializationReconciler) serviceMeshCapabilityFeatures(instance *ds
 	}
 }
 
-func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSCInitialization) feature.FeaturesProvider {
+func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSCInitialization, authorinoInstalled bool) feature.FeaturesProvider {
 	return func(registry feature.FeaturesRegistry) error {
 		serviceMeshSpec := instance.Spec.ServiceMesh
 
+		// Authorization is optional, so missing Authorino operator disables the authorization features and is reported
+		// as a warning in CapabilityServiceMeshAuthorization condition instead of failing the reconciliation.
+		authorinoIsInstalled := func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+			return authorinoInstalled, nil
+		}
+
 		return registry.Add(
+			// Extension provider is removed by its name, as it could have been added to the control plane
+			// before patch snapshots were recorded, in which case reverting the patch leaves it in place.
 			feature.Define("mesh-control-plane-external-authz").
+				NonBlocking().
+				EnabledWhen(authorinoIsInstalled).
+				Warnings(feature.EnsureOperatorIsInstalled("authorino-operator")).
 				Manifests(
 					manifest.Location(Templates.Location).
 						Include(
@@ -199,7 +208,6 @@ func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSC
 					servicemesh.FeatureData.Authorization.All(&instance.Spec)...,
 				).
 				PreConditions(
-					feature.EnsureOperatorIsInstalled("authorino-operator"),
 					servicemesh.EnsureServiceMeshInstalled,
 					servicemesh.EnsureAuthNamespaceExists,
 				).
@@ -220,6 +228,9 @@ func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSC
 			// To make it part of Service Mesh we have to patch it with injection
 			// enabled instead, otherwise it will not have proxy pod injected.
 			feature.Define("enable-proxy-injection-in-authorino-deployment").
+				NonBlocking().
+				EnabledWhen(authorinoIsInstalled).
+				DependsOn("mesh-control-plane-external-authz").
 				Manifests(
 					manifest.Location(Templates.Location).
 						Include(path.Join(Templates.AuthorinoDir, "deployment.injection.patch.tmpl.yaml")),
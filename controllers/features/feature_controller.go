@@ -0,0 +1,387 @@
+// Package features contains controller reconciling Feature custom resources, which allow to declare
+// cluster add-ons applied using the Features API without changing the operator code.
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"testing/fstest"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/oci"
+)
+
+const (
+	finalizerName = "features.opendatahub.io/finalizer"
+
+	// configMapIndex is the field index of Feature resources by the ConfigMaps holding their manifests.
+	configMapIndex = "spec.manifests.configMap"
+)
+
+// FeatureReconciler reconciles Feature custom resources.
+type FeatureReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// OCIClient fetches manifests bundled as OCI artifacts. Anonymous access over HTTPS is used if not set.
+	OCIClient *oci.Client
+	// OperatorNamespace is, next to the applications namespace, the only namespace from which manifests
+	// can be read from ConfigMaps, so Features cannot expose data of other namespaces through the operator.
+	OperatorNamespace string
+}
+
+// +kubebuilder:rbac:groups="features.opendatahub.io",resources=features,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="features.opendatahub.io",resources=features/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="features.opendatahub.io",resources=features/finalizers,verbs=update
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FeatureReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	logf.FromContext(ctx).Info("Adding controller for Feature.")
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &featurev1.Feature{}, configMapIndex, configMapsOf); err != nil {
+		return fmt.Errorf("failed indexing Features by ConfigMaps: %w", err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&featurev1.Feature{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// FeatureTracker status is updated on every apply, so only its creation and removal are of interest
+		Watches(&featurev1.FeatureTracker{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &featurev1.Feature{}),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.watchManifestsConfigMap)).
+		Complete(r)
+}
+
+// Reconcile applies the manifests of the Feature once its preconditions are met, and removes them when the Feature is deleted.
+func (r *FeatureReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx).WithName("Feature")
+	log.Info("Reconciling Feature.", "Feature Request.Name", req.Name)
+
+	instance := &featurev1.Feature{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	targetNamespace, err := r.targetNamespace(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !instance.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalize(ctx, instance, targetNamespace)
+	}
+
+	if !controllerutil.ContainsFinalizer(instance, finalizerName) {
+		controllerutil.AddFinalizer(instance, finalizerName)
+		if err := r.Client.Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if targetNamespace == "" {
+		err := errors.New("target namespace is not defined and there is no DSCInitialization to default it from")
+		_, reportErr := createFeatureReporter(r.Client, instance, targetNamespace).ReportCondition(ctx, err)
+
+		return ctrl.Result{}, errors.Join(err, reportErr)
+	}
+
+	capability := feature.NewHandlerWithReporter(
+		feature.CustomResourceFeaturesHandler(instance, targetNamespace, r.defineFeature(ctx, instance, targetNamespace)),
+		createFeatureReporter(r.Client, instance, targetNamespace),
+	)
+
+	applyErr := capability.Apply(ctx, r.Client)
+	if requeueAfter, notReady := feature.IsNotReady(applyErr); notReady {
+		log.Info("feature is not ready yet", "reason", applyErr.Error())
+
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if applyErr != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "FeatureReconcileError", "failed applying feature: %v", applyErr)
+	}
+
+	return ctrl.Result{}, applyErr
+}
+
+// defineFeature translates the Feature resource to its definition using the Features API.
+func (r *FeatureReconciler) defineFeature(ctx context.Context, instance *featurev1.Feature, targetNamespace string) feature.FeaturesProvider {
+	return func(registry feature.FeaturesRegistry) error {
+		if errConflict := r.ensureNoConflict(ctx, instance, targetNamespace); errConflict != nil {
+			return errConflict
+		}
+
+		manifests, errLoad := r.loadManifests(ctx, instance)
+		if errLoad != nil {
+			return errLoad
+		}
+
+		var preconditions []feature.Action
+		for _, precondition := range instance.Spec.PreConditions {
+			if precondition.OperatorInstalled != "" {
+				preconditions = append(preconditions, feature.EnsureOperatorIsInstalled(precondition.OperatorInstalled))
+			}
+			if precondition.CRDExists != "" {
+				preconditions = append(preconditions, feature.WaitForCRDEstablished(precondition.CRDExists))
+			}
+		}
+
+		return registry.Add(feature.Define(instance.Name).
+			NonBlocking().
+			Managed().
+			Manifests(manifest.Location(manifests).Include(".")).
+			PreConditions(preconditions...),
+		)
+	}
+}
+
+// finalize removes resources created by the feature and reverts its patches before the Feature resource is deleted.
+func (r *FeatureReconciler) finalize(ctx context.Context, instance *featurev1.Feature, targetNamespace string) error {
+	if !controllerutil.ContainsFinalizer(instance, finalizerName) {
+		return nil
+	}
+
+	// Without the target namespace there is neither FeatureTracker of the feature nor DSCInitialization (see targetNamespace),
+	// so nothing has been applied by the feature.
+	if targetNamespace != "" {
+		// Cleanup does not need the manifests, so the feature is defined without them and can be removed even when they are gone.
+		featuresHandler := feature.CustomResourceFeaturesHandler(instance, targetNamespace, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define(instance.Name))
+		})
+		if err := featuresHandler.Delete(ctx, r.Client); err != nil {
+			return err
+		}
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		newInstance := &featurev1.Feature{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), newInstance); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if controllerutil.RemoveFinalizer(newInstance, finalizerName) {
+			return r.Client.Update(ctx, newInstance)
+		}
+
+		return nil
+	})
+}
+
+// targetNamespace determines the namespace of the feature. When not set explicitly, the namespace in which the feature
+// has already been applied is used, so it can be cleaned up, and the applications namespace of DSCInitialization otherwise.
+func (r *FeatureReconciler) targetNamespace(ctx context.Context, instance *featurev1.Feature) (string, error) {
+	if instance.Spec.TargetNamespace != "" {
+		return instance.Spec.TargetNamespace, nil
+	}
+
+	trackedNamespace, err := r.trackedNamespace(ctx, instance)
+	if err != nil || trackedNamespace != "" {
+		return trackedNamespace, err
+	}
+
+	return r.applicationsNamespace(ctx)
+}
+
+// trackedNamespace returns the namespace in which the feature has been applied according to its FeatureTracker.
+// Trackers are looked up by their source when the status of the Feature does not reference one, as the status
+// could have failed to be updated after the tracker was created.
+func (r *FeatureReconciler) trackedNamespace(ctx context.Context, instance *featurev1.Feature) (string, error) {
+	if instance.Status.TrackerName != "" {
+		tracker := &featurev1.FeatureTracker{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: instance.Status.TrackerName}, tracker)
+		if err == nil && tracker.Spec.AppNamespace != "" {
+			return tracker.Spec.AppNamespace, nil
+		}
+
+		return "", client.IgnoreNotFound(err)
+	}
+
+	trackers := &featurev1.FeatureTrackerList{}
+	if err := r.Client.List(ctx, trackers); err != nil {
+		return "", fmt.Errorf("failed to retrieve FeatureTrackers of feature %q: %w", instance.Name, err)
+	}
+
+	source := featurev1.Source{Type: featurev1.FeatureType, Name: instance.Name}
+	for i := range trackers.Items {
+		if trackers.Items[i].Spec.Source == source {
+			return trackers.Items[i].Spec.AppNamespace, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *FeatureReconciler) applicationsNamespace(ctx context.Context) (string, error) {
+	instances := &dsciv1.DSCInitializationList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		return "", fmt.Errorf("failed to retrieve DSCInitialization resource: %w", err)
+	}
+
+	if len(instances.Items) == 0 {
+		return "", nil
+	}
+
+	return instances.Items[0].Spec.ApplicationsNamespace, nil
+}
+
+// ensureNoConflict prevents taking over the FeatureTracker of a feature defined by the operator itself or by other Feature resource.
+func (r *FeatureReconciler) ensureNoConflict(ctx context.Context, instance *featurev1.Feature, targetNamespace string) error {
+	tracker := featurev1.NewFeatureTracker(instance.Name, targetNamespace)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(tracker), tracker); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	expected := featurev1.Source{Type: featurev1.FeatureType, Name: instance.Name}
+	if tracker.Spec.Source != expected {
+		return fmt.Errorf("feature %q conflicts with FeatureTracker %s of %s %q",
+			instance.Name, tracker.Name, tracker.Spec.Source.Type, tracker.Spec.Source.Name)
+	}
+
+	return nil
+}
+
+// loadManifests gathers manifests from all the sources of the Feature into in-memory file system,
+// each source in its own directory. ConfigMaps are read only from the operator and the applications namespace.
+func (r *FeatureReconciler) loadManifests(ctx context.Context, instance *featurev1.Feature) (fstest.MapFS, error) {
+	manifests := fstest.MapFS{}
+
+	for i, source := range instance.Spec.Manifests {
+		switch {
+		case source.ConfigMap != nil:
+			configMap := &corev1.ConfigMap{}
+			key := types.NamespacedName{Namespace: source.ConfigMap.Namespace, Name: source.ConfigMap.Name}
+			if errAllowed := r.ensureManifestsNamespaceAllowed(ctx, key.Namespace); errAllowed != nil {
+				return nil, errAllowed
+			}
+			if err := r.Client.Get(ctx, key, configMap); err != nil {
+				return nil, fmt.Errorf("failed getting manifests from ConfigMap %s: %w", key, err)
+			}
+
+			for name, content := range configMap.Data {
+				manifests[path.Join("configmap", key.Namespace, key.Name, name)] = &fstest.MapFile{Data: []byte(content)}
+			}
+		case source.OCI != nil:
+			ociClient := r.OCIClient
+			if ociClient == nil {
+				ociClient = &oci.Client{}
+			}
+
+			files, err := ociClient.Fetch(ctx, source.OCI.Image)
+			if err != nil {
+				return nil, fmt.Errorf("failed getting manifests from OCI artifact %s: %w", source.OCI.Image, err)
+			}
+
+			for name, content := range files {
+				if isManifest(name) {
+					manifests[path.Join("oci", fmt.Sprint(i), name)] = &fstest.MapFile{Data: content}
+				}
+			}
+		default:
+			return nil, fmt.Errorf("source of manifests #%d of feature %q has no location defined", i, instance.Name)
+		}
+	}
+
+	return manifests, nil
+}
+
+// ensureManifestsNamespaceAllowed prevents reading manifests from namespaces other than the operator and the applications one,
+// as the operator can read ConfigMaps of any namespace on behalf of the creator of the Feature.
+func (r *FeatureReconciler) ensureManifestsNamespaceAllowed(ctx context.Context, namespace string) error {
+	if namespace != "" && namespace == r.OperatorNamespace {
+		return nil
+	}
+
+	applicationsNamespace, err := r.applicationsNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	if namespace != "" && namespace == applicationsNamespace {
+		return nil
+	}
+
+	return fmt.Errorf("manifests can only be read from ConfigMaps in the operator or the applications namespace, not in %q", namespace)
+}
+
+// watchManifestsConfigMap triggers reconciliation of Features using the changed ConfigMap as source of their manifests.
+func (r *FeatureReconciler) watchManifestsConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	features := &featurev1.FeatureList{}
+	if err := r.Client.List(ctx, features, client.MatchingFields{configMapIndex: configMap.GetNamespace() + "/" + configMap.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed listing Features using ConfigMap", "configmap", client.ObjectKeyFromObject(configMap))
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(features.Items))
+	for i := range features.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: features.Items[i].Name}})
+	}
+
+	return requests
+}
+
+func configMapsOf(obj client.Object) []string {
+	instance, ok := obj.(*featurev1.Feature)
+	if !ok {
+		return nil
+	}
+
+	var configMaps []string
+	for _, source := range instance.Spec.Manifests {
+		if source.ConfigMap != nil {
+			configMaps = append(configMaps, source.ConfigMap.Namespace+"/"+source.ConfigMap.Name)
+		}
+	}
+
+	return configMaps
+}
+
+func isManifest(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+func createFeatureReporter(cli client.Client, instance *featurev1.Feature, targetNamespace string) *status.Reporter[*featurev1.Feature] {
+	return status.NewStatusReporter(cli, instance, func(err error) status.SaveStatusFunc[*featurev1.Feature] {
+		return func(saved *featurev1.Feature) {
+			saved.Status.ObservedGeneration = saved.Generation
+			if targetNamespace != "" {
+				saved.Status.TrackerName = featurev1.NewFeatureTracker(saved.Name, targetNamespace).Name
+			}
+
+			switch _, notReady := feature.IsNotReady(err); {
+			case err == nil:
+				status.SetCompleteCondition(&saved.Status.Conditions, status.ReconcileCompleted, fmt.Sprintf("Applied feature [%s] successfully", saved.Name))
+				saved.Status.Phase = status.PhaseReady
+			case notReady:
+				status.SetProgressingCondition(&saved.Status.Conditions, status.ReconcileInit, fmt.Sprintf("Applying feature [%s]: %v", saved.Name, err))
+				saved.Status.Phase = status.PhaseProgressing
+			case k8serr.IsNotFound(err):
+				status.SetErrorCondition(&saved.Status.Conditions, status.ReconcileFailed, fmt.Sprintf("Missing manifests of feature [%s]: %v", saved.Name, err))
+				saved.Status.Phase = status.PhaseError
+			default:
+				status.SetErrorCondition(&saved.Status.Conditions, status.ReconcileFailed, fmt.Sprintf("Failed applying feature [%s]: %v", saved.Name, err))
+				saved.Status.Phase = status.PhaseError
+			}
+		}
+	})
+}
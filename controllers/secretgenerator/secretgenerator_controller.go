@@ -40,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	annotation "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 )
 
@@ -247,7 +248,7 @@ func (r *SecretGeneratorReconciler) createOAuthClient(ctx context.Context, name
 				return fmt.Errorf("failed to get DataScienceCluster custom resource data: %w", err)
 			}
 			if err = r.Client.Patch(ctx, oauthClient, client.RawPatch(types.ApplyPatchType, data),
-				client.ForceOwnership, client.FieldOwner("rhods-operator")); err != nil {
+				client.ForceOwnership, client.FieldOwner(cluster.FieldManager)); err != nil {
 				return fmt.Errorf("failed to patch existing OAuthClient CR: %w", err)
 			}
 			return nil
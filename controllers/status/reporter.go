@@ -40,6 +40,8 @@ func (r *Reporter[T]) ReportCondition(ctx context.Context, optionalErr error) (T
 type SaveStatusFunc[T client.Object] func(saved T)
 
 // UpdateWithRetry updates the status of object using passed function and retries on conflict.
+// The object is read again before each attempt, so the client should not read it from the cache, which can still hold
+// the stale object after a conflict. The operator manager reads such objects directly from the API server.
 func UpdateWithRetry[T client.Object](ctx context.Context, cli client.Client, original T, update SaveStatusFunc[T]) (T, error) {
 	saved, ok := original.DeepCopyObject().(T)
 	if !ok {
@@ -59,7 +59,9 @@ const (
 	ReconcileInit                         = "ReconcileInit"
 	ReconcileCompleted                    = "ReconcileCompleted"
 	ReconcileCompletedWithComponentErrors = "ReconcileCompletedWithComponentErrors"
-	ReconcileCompletedMessage             = "Reconcile completed successfully"
+	// ReconcileCompletedWithConflicts is used when component resources were applied, but some of their fields are managed by other field managers.
+	ReconcileCompletedWithConflicts = "ReconcileCompletedWithConflicts"
+	ReconcileCompletedMessage       = "Reconcile completed successfully"
 
 	// ConditionReconcileComplete represents extra Condition Type, used by .Condition.Type.
 	ConditionReconcileComplete conditionsv1.ConditionType = "ReconcileComplete"
@@ -76,6 +78,8 @@ const (
 	ConfiguredReason      string = "Configured"
 	RemovedReason         string = "Removed"
 	CapabilityFailed      string = "CapabilityFailed"
+	CapabilityProgressing string = "CapabilityProgressing"
+	CapabilityDegraded    string = "CapabilityDegraded"
 	ArgoWorkflowExist     string = "ArgoWorkflowExist"
 )
 
@@ -64,14 +64,17 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/certconfigmapgenerator"
 	dscctrl "github.com/opendatahub-io/opendatahub-operator/v2/controllers/datasciencecluster"
 	dscictrl "github.com/opendatahub-io/opendatahub-operator/v2/controllers/dscinitialization"
+	featuresctrl "github.com/opendatahub-io/opendatahub-operator/v2/controllers/features"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/secretgenerator"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/webhook"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/oci"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
 )
 
-const controllerNum = 4 // we should keep this updated if we have new controllers to add
+const controllerNum = 5 // we should keep this updated if we have new controllers to add
 
 var (
 	scheme   = runtime.NewScheme()
@@ -128,6 +131,7 @@ func main() { //nolint:funlen,maintidx
 	var dscMonitoringNamespace string
 	var operatorName string
 	var logmode string
+	var maxConcurrentComponentReconciles int
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -140,6 +144,8 @@ func main() { //nolint:funlen,maintidx
 		"monitoring stack will be deployed")
 	flag.StringVar(&operatorName, "operator-name", "opendatahub", "The name of the operator")
 	flag.StringVar(&logmode, "log-mode", "", "Log mode ('', prod, devel), default to ''")
+	flag.IntVar(&maxConcurrentComponentReconciles, "max-concurrent-component-reconciles", 1,
+		"The maximum number of data science cluster components reconciled at the same time")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -158,8 +164,8 @@ func main() { //nolint:funlen,maintidx
 		os.Exit(1)
 	}
 	// uplift default limiataions
-	setupCfg.QPS = rest.DefaultQPS * controllerNum     // 5 * 4 controllers
-	setupCfg.Burst = rest.DefaultBurst * controllerNum // 10 * 4 controllers
+	setupCfg.QPS = rest.DefaultQPS * controllerNum     // 5 * 5 controllers
+	setupCfg.Burst = rest.DefaultBurst * controllerNum // 10 * 5 controllers
 
 	setupClient, err := client.New(setupCfg, client.Options{Scheme: scheme})
 	if err != nil {
@@ -222,6 +228,14 @@ func main() { //nolint:funlen,maintidx
 		Cache:                  cacheOptions,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "07ed84f7.opendatahub.io",
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// Status of these resources is updated concurrently by component and feature workers, so they are read
+				// directly from the API server. Otherwise status.UpdateWithRetry would keep retrying with stale objects
+				// from the cache after conflicts.
+				DisableFor: []client.Object{&dscv1.DataScienceCluster{}, &dsciv1.DSCInitialization{}, &featurev1.FeatureTracker{}},
+			},
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -241,6 +255,8 @@ func main() { //nolint:funlen,maintidx
 
 	webhook.Init(mgr)
 
+	feature.SetEventRecorder(mgr.GetEventRecorderFor("features"))
+
 	if err = (&dscictrl.DSCInitializationReconciler{
 		Client:                mgr.GetClient(),
 		Scheme:                mgr.GetScheme(),
@@ -258,6 +274,7 @@ func main() { //nolint:funlen,maintidx
 			DSCISpec: &dsciv1.DSCInitializationSpec{
 				ApplicationsNamespace: dscApplicationsNamespace,
 			},
+			MaxConcurrentComponentReconciles: maxConcurrentComponentReconciles,
 		},
 		Recorder: mgr.GetEventRecorderFor("datasciencecluster-controller"),
 	}).SetupWithManager(ctx, mgr); err != nil {
@@ -281,6 +298,19 @@ func main() { //nolint:funlen,maintidx
 		os.Exit(1)
 	}
 
+	// when the operator namespace is not known, manifests of Features are read only from the applications namespace
+	operatorNs, _ := cluster.GetOperatorNamespace()
+	if err = (&featuresctrl.FeatureReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          mgr.GetEventRecorderFor("feature-controller"),
+		OCIClient:         &oci.Client{},
+		OperatorNamespace: operatorNs,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Feature")
+		os.Exit(1)
+	}
+
 	// get old release version before we create default DSCI CR
 	oldReleaseVersion, _ := upgrade.GetDeployedRelease(ctx, setupClient)
 
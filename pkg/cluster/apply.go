@@ -0,0 +1,390 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+// FieldManager is the name under which the operator owns fields of the resources it applies using server-side apply.
+const FieldManager = "opendatahub-operator"
+
+// legacyFieldManagers are the names under which the operator applied resources before FieldManager was introduced.
+// Fields they own are migrated to FieldManager (see upgradeManagedFields), so they are not reported as conflicts.
+var legacyFieldManagers = []string{"rhods-operator"}
+
+// ConflictPolicy defines how conflicts with other field managers are resolved. It is set per resource
+// using annotations.ConflictPolicy.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyForce takes over the ownership of the conflicting fields, overwriting changes made by other managers.
+	ConflictPolicyForce ConflictPolicy = "Force"
+	// ConflictPolicyYield leaves the conflicting fields to their current managers and applies only the remaining ones.
+	ConflictPolicyYield ConflictPolicy = "Yield"
+)
+
+// Conflict describes a field of the applied resource which is owned by other field manager.
+type Conflict struct {
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+	Field            string
+	Manager          string
+	// Resolution is the policy applied to the conflict.
+	Resolution ConflictPolicy
+}
+
+func (c Conflict) String() string {
+	name := c.Name
+	if c.Namespace != "" {
+		name = c.Namespace + "/" + c.Name
+	}
+
+	return fmt.Sprintf("%s %s: %s managed by %q (%s)", c.GroupVersionKind.Kind, name, c.Field, c.Manager, c.Resolution)
+}
+
+// Apply patches the current state of the resource to match the desired one using server-side apply
+// as FieldManager. The result of the patch is stored in current.
+//
+// Instead of blindly forcing the ownership, conflicts with other field managers are detected first. Fields owned
+// by ownManagers, which are other names under which the operator applied the resource in the past, or by legacyFieldManagers
+// are migrated to FieldManager and applied again. Depending on the policy defined by the annotations.ConflictPolicy
+// annotation of the current resource, the operator then either forces the ownership of the remaining conflicting
+// fields (default) or yields them. Conflicts are recorded in the ConflictRecorder carried by the context, if any,
+// so they can be reported.
+func Apply(ctx context.Context, cli client.Client, desired, current *unstructured.Unstructured, ownManagers ...string) error {
+	policy := conflictPolicyOf(current)
+
+	data, errJSON := desired.MarshalJSON()
+	if errJSON != nil {
+		return fmt.Errorf("error converting yaml to json: %w", errJSON)
+	}
+
+	errApply := cli.Patch(ctx, current, client.RawPatch(k8stypes.ApplyPatchType, data), client.FieldOwner(FieldManager))
+	conflicts, isFieldConflict := conflictsOf(errApply, desired)
+	if !isFieldConflict {
+		return errApply
+	}
+
+	formerManagers := sets.New(legacyFieldManagers...).Insert(ownManagers...).Insert(FieldManager)
+	if hasConflictWith(conflicts, formerManagers) {
+		if errUpgrade := upgradeManagedFields(ctx, cli, current, formerManagers); errUpgrade != nil {
+			return fmt.Errorf("failed migrating fields of former field managers: %w", errUpgrade)
+		}
+
+		errApply = cli.Patch(ctx, current, client.RawPatch(k8stypes.ApplyPatchType, data), client.FieldOwner(FieldManager))
+		if conflicts, isFieldConflict = conflictsOf(errApply, desired); !isFieldConflict {
+			return errApply
+		}
+	}
+
+	if policy == ConflictPolicyYield {
+		yielded := desired.DeepCopy()
+		for i := range conflicts {
+			if !removeField(yielded.Object, conflicts[i].Field) {
+				// Field cannot be located in the desired state, so the whole resource is left untouched.
+				recordConflicts(ctx, withResolution(conflicts, ConflictPolicyYield)...)
+
+				return nil
+			}
+		}
+
+		if data, errJSON = yielded.MarshalJSON(); errJSON != nil {
+			return fmt.Errorf("error converting yaml to json: %w", errJSON)
+		}
+	}
+
+	recordConflicts(ctx, withResolution(conflicts, policy)...)
+
+	return cli.Patch(ctx, current, client.RawPatch(k8stypes.ApplyPatchType, data), client.ForceOwnership, client.FieldOwner(FieldManager))
+}
+
+// upgradeManagedFields moves the ownership of the fields managed by formerManagers to FieldManager, the same way
+// csaupgrade does it for fields managed by client-side apply. Entries of formerManagers created by apply operations
+// are migrated as well, so are the fields FieldManager owns through other operations than apply (e.g. create).
+func upgradeManagedFields(ctx context.Context, cli client.Client, current *unstructured.Unstructured, formerManagers sets.Set[string]) error {
+	migrated := current.DeepCopy()
+	managedFields := migrated.GetManagedFields()
+	for i := range managedFields {
+		manager := managedFields[i].Manager
+		if managedFields[i].Operation == metav1.ManagedFieldsOperationApply && manager != FieldManager && formerManagers.Has(manager) {
+			managedFields[i].Operation = metav1.ManagedFieldsOperationUpdate
+		}
+	}
+	migrated.SetManagedFields(managedFields)
+
+	patch, errPatch := csaupgrade.UpgradeManagedFieldsPatch(migrated, formerManagers, FieldManager)
+	if errPatch != nil || patch == nil {
+		return errPatch
+	}
+
+	return cli.Patch(ctx, current, client.RawPatch(k8stypes.JSONPatchType, patch))
+}
+
+func conflictPolicyOf(obj *unstructured.Unstructured) ConflictPolicy {
+	if obj == nil {
+		return ConflictPolicyForce
+	}
+
+	if strings.EqualFold(obj.GetAnnotations()[annotations.ConflictPolicy], string(ConflictPolicyYield)) {
+		return ConflictPolicyYield
+	}
+
+	return ConflictPolicyForce
+}
+
+var conflictManagerPattern = regexp.MustCompile(`conflict with "([^"]+)"`)
+
+// conflictsOf extracts conflicts with other field managers from the error returned by the API server. Returns false
+// if the error is not caused by such conflicts, e.g. when it is a conflict of the resource version.
+func conflictsOf(err error, obj *unstructured.Unstructured) ([]Conflict, bool) {
+	var statusErr k8serr.APIStatus
+	if !k8serr.IsConflict(err) || !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil, false
+	}
+
+	var conflicts []Conflict
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		manager := cause.Message
+		if match := conflictManagerPattern.FindStringSubmatch(cause.Message); match != nil {
+			manager = match[1]
+		}
+
+		conflicts = append(conflicts, Conflict{
+			GroupVersionKind: obj.GroupVersionKind(),
+			Namespace:        obj.GetNamespace(),
+			Name:             obj.GetName(),
+			Field:            cause.Field,
+			Manager:          manager,
+		})
+	}
+
+	return conflicts, len(conflicts) > 0
+}
+
+func hasConflictWith(conflicts []Conflict, managers sets.Set[string]) bool {
+	for i := range conflicts {
+		if managers.Has(conflicts[i].Manager) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withResolution(conflicts []Conflict, policy ConflictPolicy) []Conflict {
+	for i := range conflicts {
+		conflicts[i].Resolution = policy
+	}
+
+	return conflicts
+}
+
+// removeField removes the field identified by the path, as reported in conflicts by the API server
+// (e.g. .spec.template.spec.containers[name="manager"].image), from the object. Returns false if the field is not found.
+func removeField(obj map[string]any, fieldPath string) bool {
+	return removeFromMap(obj, fieldPath)
+}
+
+func removeFromMap(obj map[string]any, fieldPath string) bool {
+	if !strings.HasPrefix(fieldPath, ".") {
+		return false
+	}
+	rest := fieldPath[1:]
+
+	// keys can contain dots (e.g. labels), so the longest key matching the path wins
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+
+	for _, key := range keys {
+		if !strings.HasPrefix(rest, key) {
+			continue
+		}
+
+		remaining := rest[len(key):]
+		if remaining == "" {
+			delete(obj, key)
+
+			return true
+		}
+
+		switch value := obj[key].(type) {
+		case map[string]any:
+			if strings.HasPrefix(remaining, ".") && removeFromMap(value, remaining) {
+				return true
+			}
+		case []any:
+			if strings.HasPrefix(remaining, "[") {
+				if updated, removed := removeFromList(value, remaining); removed {
+					obj[key] = updated
+
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func removeFromList(list []any, fieldPath string) ([]any, bool) {
+	end := strings.Index(fieldPath, "]")
+	if end < 0 {
+		return list, false
+	}
+	selector, remaining := fieldPath[1:end], fieldPath[end+1:]
+
+	for i := range list {
+		if !matchesSelector(list[i], i, selector) {
+			continue
+		}
+
+		if remaining == "" {
+			return append(list[:i:i], list[i+1:]...), true
+		}
+
+		if item, isMap := list[i].(map[string]any); isMap && removeFromMap(item, remaining) {
+			return list, true
+		}
+
+		return list, false
+	}
+
+	return list, false
+}
+
+// matchesSelector checks if the list item is identified by the selector, which is either an index (0),
+// a value of the set (="value") or a list of key fields (name="manager",protocol="TCP").
+func matchesSelector(item any, index int, selector string) bool {
+	if selectedIndex, errIndex := strconv.Atoi(selector); errIndex == nil {
+		return selectedIndex == index
+	}
+
+	if strings.HasPrefix(selector, "=") {
+		return jsonEquals(item, selector[1:])
+	}
+
+	fields, isMap := item.(map[string]any)
+	if !isMap {
+		return false
+	}
+
+	for _, keyValue := range splitKeyFields(selector) {
+		key, value, found := strings.Cut(keyValue, "=")
+		if !found || !jsonEquals(fields[key], value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func jsonEquals(value any, encoded string) bool {
+	var expected any
+	if err := json.Unmarshal([]byte(encoded), &expected); err != nil {
+		return false
+	}
+
+	actual, errActual := json.Marshal(value)
+	expectedJSON, errExpected := json.Marshal(expected)
+
+	return errActual == nil && errExpected == nil && string(actual) == string(expectedJSON)
+}
+
+// splitKeyFields splits the selector on commas which are not part of quoted values.
+func splitKeyFields(selector string) []string {
+	var parts []string
+	inQuotes, start := false, 0
+	for i := 0; i < len(selector); i++ {
+		switch selector[i] {
+		case '\\':
+			i++
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, selector[start:])
+}
+
+// ConflictRecorder collects conflicts detected while applying resources, so they can be reported
+// e.g. in the status of the component or the FeatureTracker.
+type ConflictRecorder struct {
+	mu        sync.Mutex
+	conflicts []Conflict
+}
+
+type conflictRecorderKey struct{}
+
+// WithConflictRecorder returns a context carrying a new ConflictRecorder, which collects conflicts detected by Apply.
+func WithConflictRecorder(ctx context.Context) (context.Context, *ConflictRecorder) {
+	recorder := &ConflictRecorder{}
+
+	return context.WithValue(ctx, conflictRecorderKey{}, recorder), recorder
+}
+
+// Conflicts returns all the recorded conflicts.
+func (r *ConflictRecorder) Conflicts() []Conflict {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Conflict(nil), r.conflicts...)
+}
+
+// Err returns an error describing the recorded conflicts, or nil if there were none.
+func (r *ConflictRecorder) Err() error {
+	conflicts := r.Conflicts()
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(conflicts))
+	for i := range conflicts {
+		descriptions = append(descriptions, conflicts[i].String())
+	}
+
+	return fmt.Errorf("conflicts with other field managers: %s", strings.Join(descriptions, "; "))
+}
+
+func recordConflicts(ctx context.Context, conflicts ...Conflict) {
+	for i := range conflicts {
+		logf.FromContext(ctx).Info("field managed by other field manager", "conflict", conflicts[i].String())
+	}
+
+	if recorder, found := ctx.Value(conflictRecorderKey{}).(*ConflictRecorder); found {
+		recorder.mu.Lock()
+		recorder.conflicts = append(recorder.conflicts, conflicts...)
+		recorder.mu.Unlock()
+	}
+}
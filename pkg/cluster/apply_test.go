@@ -0,0 +1,240 @@
+package cluster_test
+
+import (
+	"reflect"
+	"testing"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+func TestRemoveField(t *testing.T) {
+	deployment := func() map[string]any {
+		return map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{"app.kubernetes.io/name": "dashboard", "app": "dashboard"},
+			},
+			"spec": map[string]any{
+				"replicas": int64(2),
+				"template": map[string]any{
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{"name": "manager", "image": "operator:latest"},
+							map[string]any{"name": "proxy", "image": "proxy:latest"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		field    string
+		removed  bool
+		expected func() map[string]any
+	}{
+		"Simple field": {
+			field:   ".spec.replicas",
+			removed: true,
+			expected: func() map[string]any {
+				obj := deployment()
+				delete(obj["spec"].(map[string]any), "replicas")
+
+				return obj
+			},
+		},
+		"Key containing dots": {
+			field:   ".metadata.labels.app.kubernetes.io/name",
+			removed: true,
+			expected: func() map[string]any {
+				obj := deployment()
+				delete(obj["metadata"].(map[string]any)["labels"].(map[string]any), "app.kubernetes.io/name")
+
+				return obj
+			},
+		},
+		"Field of list item selected by key": {
+			field:   `.spec.template.spec.containers[name="proxy"].image`,
+			removed: true,
+			expected: func() map[string]any {
+				obj := deployment()
+				containers := obj["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)
+				delete(containers[1].(map[string]any), "image")
+
+				return obj
+			},
+		},
+		"Whole list item selected by key": {
+			field:   `.spec.template.spec.containers[name="manager"]`,
+			removed: true,
+			expected: func() map[string]any {
+				obj := deployment()
+				podSpec := obj["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)
+				podSpec["containers"] = []any{podSpec["containers"].([]any)[1]}
+
+				return obj
+			},
+		},
+		"Missing field": {
+			field:    ".spec.paused",
+			expected: deployment,
+		},
+		"List item not matching the selector": {
+			field:    `.spec.template.spec.containers[name="sidecar"].image`,
+			expected: deployment,
+		},
+		"Path without leading dot": {
+			field:    "spec.replicas",
+			expected: deployment,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := deployment()
+
+			removed := cluster.RemoveField(obj, tc.field)
+
+			if removed != tc.removed {
+				t.Errorf("expected removed to be %v, got %v", tc.removed, removed)
+			}
+			if expected := tc.expected(); !reflect.DeepEqual(obj, expected) {
+				t.Errorf("expected %v, got %v", expected, obj)
+			}
+		})
+	}
+}
+
+func TestRemoveFromList(t *testing.T) {
+	cases := map[string]struct {
+		list     []any
+		field    string
+		removed  bool
+		expected []any
+	}{
+		"Item selected by index": {
+			list:     []any{"a", "b", "c"},
+			field:    "[1]",
+			removed:  true,
+			expected: []any{"a", "c"},
+		},
+		"Item selected by value": {
+			list:     []any{"a", "b", "c"},
+			field:    `[="c"]`,
+			removed:  true,
+			expected: []any{"a", "b"},
+		},
+		"Field of item selected by index": {
+			list:     []any{map[string]any{"name": "a", "value": "x"}},
+			field:    "[0].value",
+			removed:  true,
+			expected: []any{map[string]any{"name": "a"}},
+		},
+		"Index out of range": {
+			list:     []any{"a"},
+			field:    "[3]",
+			expected: []any{"a"},
+		},
+		"Unterminated selector": {
+			list:     []any{"a"},
+			field:    "[0",
+			expected: []any{"a"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated, removed := cluster.RemoveFromList(tc.list, tc.field)
+
+			if removed != tc.removed {
+				t.Errorf("expected removed to be %v, got %v", tc.removed, removed)
+			}
+			if !reflect.DeepEqual(updated, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, updated)
+			}
+		})
+	}
+}
+
+func TestMatchesSelector(t *testing.T) {
+	port := map[string]any{"containerPort": int64(8080), "protocol": "TCP", "name": "http,alt"}
+
+	cases := map[string]struct {
+		item     any
+		index    int
+		selector string
+		matches  bool
+	}{
+		"Matching index":             {item: "a", index: 2, selector: "2", matches: true},
+		"Other index":                {item: "a", index: 1, selector: "2"},
+		"Matching set value":         {item: "a", selector: `="a"`, matches: true},
+		"Other set value":            {item: "a", selector: `="b"`},
+		"Matching key fields":        {item: port, selector: `containerPort=8080,protocol="TCP"`, matches: true},
+		"Key field with other value": {item: port, selector: `containerPort=8080,protocol="UDP"`},
+		"Quoted comma in value":      {item: port, selector: `name="http,alt"`, matches: true},
+		"Key fields of scalar item":  {item: "a", selector: `name="a"`},
+		"Malformed key field":        {item: port, selector: "protocol"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if matches := cluster.MatchesSelector(tc.item, tc.index, tc.selector); matches != tc.matches {
+				t.Errorf("expected %q to match: %v, got %v", tc.selector, tc.matches, matches)
+			}
+		})
+	}
+}
+
+func TestConflictsOf(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	obj.SetNamespace("opendatahub")
+	obj.SetName("dashboard")
+	resource := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	fieldConflict := k8serr.NewApplyConflict([]metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "gitops" using apps/v1`,
+		Field:   ".spec.replicas",
+	}}, "Apply failed with 1 conflict")
+
+	cases := map[string]struct {
+		err       error
+		conflicts []cluster.Conflict
+	}{
+		"Conflict with other field manager": {
+			err: fieldConflict,
+			conflicts: []cluster.Conflict{{
+				GroupVersionKind: obj.GroupVersionKind(),
+				Namespace:        "opendatahub",
+				Name:             "dashboard",
+				Field:            ".spec.replicas",
+				Manager:          "gitops",
+			}},
+		},
+		"Conflict of resource version": {
+			err: k8serr.NewConflict(resource, "dashboard", nil),
+		},
+		"Other error": {
+			err: k8serr.NewNotFound(resource, "dashboard"),
+		},
+		"No error": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			conflicts, isFieldConflict := cluster.ConflictsOf(tc.err, obj)
+
+			if isFieldConflict != (len(tc.conflicts) > 0) {
+				t.Errorf("expected field conflict: %v, got %v", len(tc.conflicts) > 0, isFieldConflict)
+			}
+			if !reflect.DeepEqual(conflicts, tc.conflicts) {
+				t.Errorf("expected %v, got %v", tc.conflicts, conflicts)
+			}
+		})
+	}
+}
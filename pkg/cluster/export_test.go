@@ -0,0 +1,8 @@
+package cluster
+
+var (
+	RemoveField     = removeField
+	RemoveFromList  = removeFromList
+	MatchesSelector = matchesSelector
+	ConflictsOf     = conflictsOf
+)
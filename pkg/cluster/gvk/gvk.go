@@ -9,6 +9,12 @@ var (
 		Kind:    "ClusterServiceVersion",
 	}
 
+	CustomResourceDefinition = schema.GroupVersionKind{
+		Group:   "apiextensions.k8s.io",
+		Version: "v1",
+		Kind:    "CustomResourceDefinition",
+	}
+
 	DataScienceCluster = schema.GroupVersionKind{
 		Group:   "datasciencecluster.opendatahub.io",
 		Version: "v1",
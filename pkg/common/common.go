@@ -22,48 +22,40 @@ import (
 	"crypto/sha256"
 	b64 "encoding/base64"
 	"fmt"
-	"os"
 	"regexp"
 	"strings"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy/overlay"
 )
 
 // ReplaceStringsInFile replaces variable with value in manifests during runtime.
+// The file on disk is left intact, replacements are set in overlay.Manifests and replace the previous values.
 func ReplaceStringsInFile(fileName string, replacements map[string]string) error {
-	// Read the contents of the file
-	fileContent, err := os.ReadFile(fileName)
-	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
-	}
-
 	// Replace all occurrences of the strings in the map
-	newContent := string(fileContent)
 	for string1, string2 := range replacements {
-		newContent = strings.ReplaceAll(newContent, string1, string2)
-	}
-
-	// Write the modified content back to the file
-	err = os.WriteFile(fileName, []byte(newContent), 0)
-	if err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
+		string1, string2 := string1, string2
+		err := overlay.Manifests.Set(fileName, "replace/"+string1, func(content []byte) ([]byte, error) {
+			return []byte(strings.ReplaceAll(string(content), string1, string2)), nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to replace %s in file: %w", string1, err)
+		}
 	}
 
 	return nil
 }
 
 // MatchLineInFile use the 'key' of the replacements as match pattern and replace the line with 'value'.
+// The file on disk is left intact, lines are replaced in overlay.Manifests.
 func MatchLineInFile(fileName string, replacements map[string]string) error {
-	fileContent, err := os.ReadFile(fileName)
-	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
-	}
-	newContent := string(fileContent)
 	for matchPattern, NewValue := range replacements {
-		re := regexp.MustCompile(matchPattern + `(.*)`)
-		newContent = re.ReplaceAllString(newContent, NewValue)
-	}
-	err = os.WriteFile(fileName, []byte(newContent), 0)
-	if err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
+		re, NewValue := regexp.MustCompile(matchPattern+`(.*)`), NewValue
+		err := overlay.Manifests.Set(fileName, "match/"+matchPattern, func(content []byte) ([]byte, error) {
+			return []byte(re.ReplaceAllString(string(content), NewValue)), nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to replace lines matching %s in file: %w", matchPattern, err)
+		}
 	}
 
 	return nil
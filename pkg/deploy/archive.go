@@ -0,0 +1,303 @@
+package deploy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ArchiveLimits bound the content extracted from manifests archives.
+type ArchiveLimits struct {
+	// MaxFiles is the maximum number of regular files in the archive.
+	MaxFiles int
+	// MaxFileSize is the maximum size of a single file in bytes.
+	MaxFileSize int64
+	// MaxTotalSize is the maximum size of all files in bytes.
+	MaxTotalSize int64
+}
+
+// TarballLimits are enforced when extracting manifests tarballs.
+var TarballLimits = ArchiveLimits{
+	MaxFiles:     10000,
+	MaxFileSize:  10 << 20,
+	MaxTotalSize: 256 << 20,
+}
+
+// extractTarball extracts gzipped tar archive to the directory, stripping the given number of leading path components.
+// Only directories and regular files are extracted, links, absolute paths and paths escaping the directory are rejected,
+// and the content is bounded by TarballLimits. The directory is expected to be a staging one, as it is left
+// partially populated when the extraction fails.
+func extractTarball(reader io.Reader, dir string, stripComponents int) error {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("error creating gzip reader: %w", err)
+	}
+	defer gzipReader.Close()
+
+	var files int
+	var totalSize int64
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, errNext := tarReader.Next()
+		if errors.Is(errNext, io.EOF) {
+			return nil
+		}
+		if errNext != nil {
+			return fmt.Errorf("error reading tarball: %w", errNext)
+		}
+
+		// pax global header carries only metadata, e.g. commit id of tarballs generated by GitHub
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		relativePath, errPath := archiveEntryPath(header.Name, stripComponents)
+		if errPath != nil {
+			return errPath
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if relativePath == "" {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Join(dir, relativePath), os.ModePerm); err != nil {
+				return fmt.Errorf("error creating directory: %w", err)
+			}
+		case tar.TypeReg:
+			if relativePath == "" {
+				return fmt.Errorf("tarball entry %q is outside of the stripped directory", header.Name)
+			}
+
+			files++
+			totalSize += header.Size
+			switch {
+			case files > TarballLimits.MaxFiles:
+				return fmt.Errorf("tarball exceeds the limit of %d files", TarballLimits.MaxFiles)
+			case header.Size > TarballLimits.MaxFileSize:
+				return fmt.Errorf("tarball entry %q exceeds the limit of %d bytes", header.Name, TarballLimits.MaxFileSize)
+			case totalSize > TarballLimits.MaxTotalSize:
+				return fmt.Errorf("tarball exceeds the limit of %d bytes in total", TarballLimits.MaxTotalSize)
+			}
+
+			if err := writeFile(filepath.Join(dir, relativePath), io.LimitReader(tarReader, header.Size)); err != nil {
+				return err
+			}
+		case tar.TypeSymlink, tar.TypeLink:
+			return fmt.Errorf("tarball entry %q is a link, links are not allowed", header.Name)
+		default:
+			return fmt.Errorf("tarball entry %q has unsupported type %q", header.Name, header.Typeflag)
+		}
+	}
+}
+
+// archiveEntryPath validates the name of the archive entry and returns its local path with the leading
+// path components stripped. Empty path is returned for entries which are stripped entirely.
+func archiveEntryPath(name string, stripComponents int) (string, error) {
+	if path.IsAbs(name) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("tarball entry %q has an absolute path", name)
+	}
+
+	nameParts := strings.Split(strings.Trim(strings.ReplaceAll(name, `\`, "/"), "/"), "/")
+	for _, part := range nameParts {
+		if part == ".." {
+			return "", fmt.Errorf("tarball entry %q escapes the target directory", name)
+		}
+	}
+
+	if len(nameParts) <= stripComponents {
+		return "", nil
+	}
+
+	relativePath := filepath.Clean(filepath.FromSlash(strings.Join(nameParts[stripComponents:], "/")))
+	if relativePath == "." {
+		return "", nil
+	}
+
+	return relativePath, nil
+}
+
+// installedManifests tracks digests of the sources installed to the override directories, so unchanged sources are
+// not installed again on every reconciliation, and serializes installs to the same directory, e.g. odh-model-controller
+// manifests installed by both kserve and modelmeshserving.
+var installedManifests = &installs{targets: map[string]*installTarget{}}
+
+type installs struct {
+	mu      sync.Mutex
+	targets map[string]*installTarget
+}
+
+type installTarget struct {
+	mu     sync.Mutex
+	digest string
+}
+
+func (i *installs) target(componentDir string) *installTarget {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	target, found := i.targets[componentDir]
+	if !found {
+		target = &installTarget{}
+		i.targets[componentDir] = target
+	}
+
+	return target
+}
+
+// install installs the manifests unless the same source has already been installed to the component directory,
+// see installManifests. It reports whether the component directory has changed.
+func (i *installs) install(sourceDir, baseDir, componentDir string) (bool, error) {
+	target := i.target(componentDir)
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	if info, err := os.Stat(sourceDir); err != nil || !info.IsDir() {
+		return false, fmt.Errorf("manifests directory %s not found in the manifests source", sourceDir)
+	}
+
+	hasher := sha256.New()
+	if err := hashDirectory(hasher, sourceDir); err != nil {
+		return false, fmt.Errorf("failed computing content hash of %s: %w", sourceDir, err)
+	}
+	digest := hex.EncodeToString(hasher.Sum(nil))
+
+	if _, err := os.Stat(componentDir); err == nil && target.digest == digest {
+		return false, nil
+	}
+
+	// digest is cleared first, so the directory is installed again after a failed swap
+	target.digest = ""
+	if err := installManifests(sourceDir, baseDir, componentDir); err != nil {
+		return false, err
+	}
+	target.digest = digest
+
+	return true, nil
+}
+
+// installManifests copies the manifests to a staging directory next to the component directory, which replaces
+// the component directory only when the copy succeeded. The staging directory is seeded with the manifests from
+// baseDir, so files the source does not provide, e.g. params.env, are carried over from the shipped manifests.
+func installManifests(sourceDir, baseDir, componentDir string) error {
+	if info, err := os.Stat(sourceDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("manifests directory %s not found in the manifests source", sourceDir)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(componentDir), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating manifests directory: %w", err)
+	}
+
+	stagingDir, err := os.MkdirTemp(filepath.Dir(componentDir), "."+filepath.Base(componentDir)+"-staging-")
+	if err != nil {
+		return fmt.Errorf("error creating staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err = os.Chmod(stagingDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(baseDir); err == nil {
+		if err = copyManifests(baseDir, stagingDir); err != nil {
+			return fmt.Errorf("error copying existing manifests: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err = copyManifests(sourceDir, stagingDir); err != nil {
+		return err
+	}
+
+	return swapDirectory(stagingDir, componentDir)
+}
+
+// swapDirectory replaces the directory with the staging one, restoring the previous content on failure.
+func swapDirectory(stagingDir, dir string) error {
+	previousDir := stagingDir + "-previous"
+	if err := os.Rename(dir, previousDir); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error replacing manifests directory: %w", err)
+		}
+		previousDir = ""
+	}
+
+	if err := os.Rename(stagingDir, dir); err != nil {
+		if previousDir != "" {
+			_ = os.Rename(previousDir, dir)
+		}
+
+		return fmt.Errorf("error replacing manifests directory: %w", err)
+	}
+
+	if previousDir != "" {
+		return os.RemoveAll(previousDir)
+	}
+
+	return nil
+}
+
+// copyManifests copies regular files of the source directory tree to the destination, overwriting existing files.
+func copyManifests(sourceDir, destinationDir string) error {
+	return filepath.WalkDir(sourceDir, func(filePath string, entry fs.DirEntry, errWalk error) error {
+		if errWalk != nil {
+			return errWalk
+		}
+
+		relativePath, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destinationDir, relativePath)
+
+		if entry.IsDir() {
+			return os.MkdirAll(target, os.ModePerm)
+		}
+
+		if !entry.Type().IsRegular() {
+			logf.Log.V(1).Info("skipping non-regular file in manifests source", "path", filePath)
+
+			return nil
+		}
+
+		source, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer source.Close()
+
+		return writeFile(target, source)
+	})
+}
+
+func writeFile(path string, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err = io.Copy(file, content); err != nil {
+		return fmt.Errorf("error writing file contents: %w", err)
+	}
+
+	return nil
+}
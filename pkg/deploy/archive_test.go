@@ -0,0 +1,165 @@
+package deploy_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Extracting manifests tarballs", func() {
+
+	var (
+		manifestsDir string
+		stockDir     string
+		overridesDir string
+		componentDir string
+		tarball      []byte
+		server       *httptest.Server
+	)
+
+	BeforeEach(func() {
+		manifestsDir, overridesDir = GinkgoT().TempDir(), GinkgoT().TempDir()
+		stockDir, componentDir = filepath.Join(manifestsDir, "component"), filepath.Join(overridesDir, "component")
+		originalManifestPath, originalOverridePath := deploy.DefaultManifestPath, deploy.OverrideManifestPath
+		originalCacheDir, originalLimits := deploy.ManifestsCacheDir, deploy.TarballLimits
+		deploy.DefaultManifestPath, deploy.OverrideManifestPath, deploy.ManifestsCacheDir = manifestsDir, overridesDir, GinkgoT().TempDir()
+		DeferCleanup(func() {
+			deploy.DefaultManifestPath, deploy.OverrideManifestPath = originalManifestPath, originalOverridePath
+			deploy.ManifestsCacheDir, deploy.TarballLimits = originalCacheDir, originalLimits
+		})
+
+		// manifests shipped with the operator, which have to stay untouched
+		Expect(os.MkdirAll(filepath.Join(stockDir, "base"), os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(stockDir, "base", "kustomization.yaml"), []byte("resources: [original.yaml]"), 0o600)).To(Succeed())
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(tarball)
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	download := func(ctx context.Context) error {
+		return deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        server.URL + "/tarball/main",
+			ContextDir: "manifests",
+		})
+	}
+
+	expectOriginalManifests := func() {
+		GinkgoHelper()
+		Expect(os.ReadFile(filepath.Join(stockDir, "base", "kustomization.yaml"))).To(BeEquivalentTo("resources: [original.yaml]"))
+		Expect(filepath.Join(stockDir, "overlays")).ToNot(BeAnExistingFile())
+		Expect(componentDir).ToNot(BeAnExistingFile())
+		Expect(filepath.Glob(filepath.Join(overridesDir, ".component-staging-*"))).To(BeEmpty())
+	}
+
+	It("should install manifests next to the shipped ones and keep files of the component not present in the tarball", func(ctx context.Context) {
+		// given
+		tarball = createTarball(
+			tarEntry{Header: &tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": "1234"}, Format: tar.FormatPAX}},
+			tarEntry{Header: &tar.Header{Typeflag: tar.TypeDir, Name: "org-repo-1234/", Mode: 0o755}},
+			regularFile("org-repo-1234/manifests/base/kustomization.yaml", "resources: [updated.yaml]"),
+			regularFile("org-repo-1234/manifests/overlays/kustomization.yaml", "resources: [../base]"),
+		)
+		Expect(os.WriteFile(filepath.Join(stockDir, "params.env"), []byte("image=quay.io/org/image"), 0o600)).To(Succeed())
+
+		// when
+		err := download(ctx)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.ReadFile(filepath.Join(componentDir, "base", "kustomization.yaml"))).To(BeEquivalentTo("resources: [updated.yaml]"))
+		Expect(filepath.Join(componentDir, "overlays", "kustomization.yaml")).To(BeARegularFile())
+		Expect(filepath.Join(componentDir, "params.env")).To(BeARegularFile())
+		Expect(filepath.Glob(filepath.Join(overridesDir, ".component-staging-*"))).To(BeEmpty())
+		Expect(os.ReadFile(filepath.Join(stockDir, "base", "kustomization.yaml"))).To(BeEquivalentTo("resources: [original.yaml]"))
+		Expect(filepath.Join(stockDir, "overlays")).ToNot(BeAnExistingFile())
+	})
+
+	DescribeTable("should reject unsafe tarball leaving existing manifests untouched",
+		func(ctx context.Context, expectedErr string, entries ...tarEntry) {
+			// given
+			tarball = createTarball(append([]tarEntry{
+				regularFile("org-repo-1234/manifests/overlays/kustomization.yaml", "resources: [../base]"),
+			}, entries...)...)
+
+			// when
+			err := download(ctx)
+
+			// then
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			expectOriginalManifests()
+		},
+		Entry("path traversal", "escapes the target directory",
+			regularFile("org-repo-1234/../../etc/kustomization.yaml", "resources: []")),
+		Entry("absolute path", "has an absolute path",
+			regularFile("/etc/kustomization.yaml", "resources: []")),
+		Entry("symbolic link", "links are not allowed",
+			tarEntry{Header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "org-repo-1234/manifests/base/link", Linkname: "/etc/passwd"}}),
+		Entry("hard link", "links are not allowed",
+			tarEntry{Header: &tar.Header{Typeflag: tar.TypeLink, Name: "org-repo-1234/manifests/base/link", Linkname: "org-repo-1234/manifests/overlays/kustomization.yaml"}}),
+		Entry("device file", "unsupported type",
+			tarEntry{Header: &tar.Header{Typeflag: tar.TypeChar, Name: "org-repo-1234/manifests/base/device"}}),
+	)
+
+	DescribeTable("should enforce limits leaving existing manifests untouched",
+		func(ctx context.Context, limits deploy.ArchiveLimits, expectedErr string) {
+			// given
+			deploy.TarballLimits = limits
+			tarball = createTarball(
+				regularFile("org-repo-1234/manifests/base/kustomization.yaml", "resources: [updated.yaml]"),
+				regularFile("org-repo-1234/manifests/overlays/kustomization.yaml", "resources: [../base]"),
+			)
+
+			// when
+			err := download(ctx)
+
+			// then
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			expectOriginalManifests()
+		},
+		Entry("number of files", deploy.ArchiveLimits{MaxFiles: 1, MaxFileSize: 1024, MaxTotalSize: 1024}, "limit of 1 files"),
+		Entry("file size", deploy.ArchiveLimits{MaxFiles: 10, MaxFileSize: 8, MaxTotalSize: 1024}, "exceeds the limit of 8 bytes"),
+		Entry("total size", deploy.ArchiveLimits{MaxFiles: 10, MaxFileSize: 1024, MaxTotalSize: 30}, "limit of 30 bytes in total"),
+	)
+})
+
+type tarEntry struct {
+	*tar.Header
+	content string
+}
+
+func regularFile(name, content string) tarEntry {
+	return tarEntry{
+		Header:  &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o600, Size: int64(len(content))},
+		content: content,
+	}
+}
+
+func createTarball(entries ...tarEntry) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, entry := range entries {
+		Expect(tarWriter.WriteHeader(entry.Header)).To(Succeed())
+		_, err := tarWriter.Write([]byte(entry.content))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+
+	return buf.Bytes()
+}
@@ -18,16 +18,9 @@ limitations under the License.
 package deploy
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 
 	"golang.org/x/exp/maps"
@@ -39,12 +32,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/kustomize/api/krusty"
-	"sigs.k8s.io/kustomize/api/resmap"
 	"sigs.k8s.io/kustomize/api/resource"
-	"sigs.k8s.io/kustomize/kyaml/filesys"
 
-	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/conversion"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
@@ -55,98 +45,6 @@ var (
 	DefaultManifestPath = os.Getenv("DEFAULT_MANIFESTS_PATH")
 )
 
-// DownloadManifests function performs following tasks:
-// 1. It takes component URI and only downloads folder specified by component.ContextDir field
-// 2. It saves the manifests in the odh-manifests/component-name/ folder.
-func DownloadManifests(ctx context.Context, componentName string, manifestConfig components.ManifestsConfig) error {
-	// Get the component repo from the given url
-	// e.g.  https://github.com/example/tarball/master
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestConfig.URI, nil)
-	if err != nil {
-		return err
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error downloading manifests: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("error downloading manifests: %v HTTP status", resp.StatusCode)
-	}
-
-	// Create a new gzip reader
-	gzipReader, err := gzip.NewReader(resp.Body)
-	if err != nil {
-		return fmt.Errorf("error creating gzip reader: %w", err)
-	}
-	defer gzipReader.Close()
-
-	// Create a new TAR reader
-	tarReader := tar.NewReader(gzipReader)
-
-	// Create manifest directory
-	mode := os.ModePerm
-	err = os.MkdirAll(DefaultManifestPath, mode)
-	if err != nil {
-		return fmt.Errorf("error creating manifests directory : %w", err)
-	}
-
-	// Extract the contents of the TAR archive to the current directory
-	for {
-		header, err := tarReader.Next()
-		if errors.Is(err, io.EOF) {
-			break
-		}
-		if err != nil {
-			return err
-		}
-
-		componentFiles := strings.Split(header.Name, "/")
-		componentFileName := header.Name
-		componentManifestPath := componentFiles[0] + "/" + manifestConfig.ContextDir
-
-		if strings.Contains(componentFileName, componentManifestPath) {
-			// Get manifest path relative to repo
-			// e.g. of repo/a/b/manifests/base --> base/
-			componentFoldersList := strings.Split(componentFileName, "/")
-			componentFileRelativePathFound := strings.Join(componentFoldersList[len(strings.Split(componentManifestPath, "/")):], "/")
-
-			if header.Typeflag == tar.TypeDir {
-				err = os.MkdirAll(DefaultManifestPath+"/"+componentName+"/"+componentFileRelativePathFound, mode)
-				if err != nil {
-					return fmt.Errorf("error creating directory:%w", err)
-				}
-
-				continue
-			}
-
-			if header.Typeflag == tar.TypeReg {
-				file, err := os.Create(DefaultManifestPath + "/" + componentName + "/" + componentFileRelativePathFound)
-				if err != nil {
-					return fmt.Errorf("error creating file: %w", err)
-				}
-
-				defer file.Close()
-
-				for {
-					_, err := io.CopyN(file, tarReader, 1024)
-					if err != nil {
-						if errors.Is(err, io.EOF) {
-							break
-						}
-						return fmt.Errorf("error downloading file contents: %w", err)
-					}
-				}
-
-				continue
-			}
-		}
-	}
-
-	return err
-}
-
 func DeployManifestsFromPath(
 	ctx context.Context,
 	cli client.Client,
@@ -156,41 +54,41 @@ func DeployManifestsFromPath(
 	componentName string,
 	componentEnabled bool,
 ) error {
-	// Render the Kustomize manifests
-	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
-	fs := filesys.MakeFsOnDisk()
-	// Create resmap
-	// Use kustomization file under manifestPath or use `default` overlay
-	var resMap resmap.ResMap
-	_, err := os.Stat(filepath.Join(manifestPath, "kustomization.yaml"))
+	resMap, err := RenderManifests(manifestPath, namespace, componentName)
 	if err != nil {
-		if !os.IsNotExist(err) {
-			return err
-		}
-		manifestPath = filepath.Join(manifestPath, "default")
+		return err
 	}
 
-	resMap, err = k.Run(fs, manifestPath)
-	if err != nil {
-		return err
+	if componentEnabled {
+		recordRendered(ctx, componentName, resMap)
 	}
 
-	nsPlugin := plugins.CreateNamespaceApplierPlugin(namespace)
-	if err := nsPlugin.Transform(resMap); err != nil {
-		return fmt.Errorf("failed applying namespace plugin when preparing Kustomize resources. %w", err)
+	plan, planning := planOf(ctx)
+	if planning {
+		cli = plan.client(cli, componentName)
 	}
 
-	labelsPlugin := plugins.CreateAddLabelsPlugin(componentName)
-	if err := labelsPlugin.Transform(resMap); err != nil {
-		return fmt.Errorf("failed applying labels plugin when preparing Kustomize resources. %w", err)
+	// Create / apply resources phase by phase, so custom resources are applied once their CRDs are established.
+	// Resources of disabled components are deleted in the reverse order.
+	phases := groupByPhase(cli, resMap.Resources())
+	order := []applyPhase{phaseNamespaces, phaseCRDs, phaseRBAC, phaseConfig, phaseWorkloads, phaseCustomResources}
+	if !componentEnabled {
+		slices.Reverse(order)
 	}
 
-	// Create / apply / delete resources in the cluster
-	for _, res := range resMap.Resources() {
-		err = manageResource(ctx, cli, res, owner, namespace, componentName, componentEnabled)
-		if err != nil {
+	for _, phase := range order {
+		if len(phases[phase]) == 0 {
+			continue
+		}
+		if err := manageResources(ctx, cli, phase, phases[phase], owner, namespace, componentName, componentEnabled); err != nil {
 			return err
 		}
+		// CRDs are not created when planning, their custom resources are reported using the rendered kinds
+		if phase == phaseCRDs && componentEnabled && !planning {
+			if err := waitForCRDsEstablished(ctx, cli, phases[phase]); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -203,6 +101,20 @@ func manageResource(ctx context.Context, cli client.Client, res *resource.Resour
 	}
 
 	found, err := getResource(ctx, cli, res)
+	if meta.IsNoMatchError(err) {
+		// nothing to delete when the kind is not served
+		if !enabled {
+			return nil
+		}
+		// kinds defined by CRDs which are only planned to be created are not served either
+		if plan, planning := planOf(ctx); planning {
+			plan.recordUnserved(componentName, res)
+
+			return nil
+		}
+
+		return err
+	}
 
 	if err == nil {
 		// when resource is found
@@ -243,10 +155,6 @@ func getResource(ctx context.Context, cli client.Client, obj *resource.Resource)
 	found.SetGroupVersionKind(gvk)
 
 	err := cli.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, found)
-	if errors.Is(err, &meta.NoKindMatchError{}) {
-		// convert the error to NotFound to handle both the same way in the caller
-		return nil, k8serr.NewNotFound(schema.GroupResource{Group: gvk.Group}, obj.GetName())
-	}
 	if err != nil {
 		return nil, err
 	}
@@ -285,7 +193,7 @@ func createResource(ctx context.Context, cli client.Client, res *resource.Resour
 			return err
 		}
 	}
-	return cli.Create(ctx, obj)
+	return cli.Create(ctx, obj, client.FieldOwner(cluster.FieldManager))
 }
 
 // Exception to skip ODHDashboardConfig CR reconcile.
@@ -337,14 +245,10 @@ func updateLabels(found, obj *unstructured.Unstructured) {
 	obj.SetLabels(foundLabels)
 }
 
-// preformPatch works for update cases.
+// performPatch works for update cases.
 func performPatch(ctx context.Context, cli client.Client, obj, found *unstructured.Unstructured, owner metav1.Object) error {
-	data, err := json.Marshal(obj)
-	if err != nil {
-		return err
-	}
-	// force owner to be default-dsc/default-dsci
-	return cli.Patch(ctx, found, client.RawPatch(types.ApplyPatchType, data), client.ForceOwnership, client.FieldOwner(owner.GetName()))
+	// fields were previously applied using the name of the owner (e.g. default-dsc/default-dsci) as field manager
+	return cluster.Apply(ctx, cli, obj, found, owner.GetName())
 }
 
 // TODO : Add function to cleanup code created as part of pre install and post install task of a component
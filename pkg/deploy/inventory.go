@@ -0,0 +1,309 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const inventoryKey = "resources"
+
+// Inventory collects resources rendered by DeployManifestsFromPath during a single reconciliation of a component,
+// grouped by the app.opendatahub.io/<component> label they are deployed with, so resources which are not part
+// of the component manifests anymore can be pruned.
+type Inventory struct {
+	owner     metav1.Object
+	namespace string
+
+	mu       sync.Mutex
+	rendered map[string][]InventoryEntry
+}
+
+// InventoryEntry identifies a resource recorded in the Inventory.
+type InventoryEntry struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (e InventoryEntry) groupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: e.Group, Version: e.Version, Kind: e.Kind}
+}
+
+// objectKey identifies the resource regardless of the API version it is served with.
+type objectKey struct {
+	group, kind, namespace, name string
+}
+
+type inventoryContextKey struct{}
+
+// WithInventory returns a context carrying a new Inventory, which collects resources rendered for the owner.
+// Recorded resources are persisted in ConfigMaps in the given namespace when pruning.
+func WithInventory(ctx context.Context, owner metav1.Object, namespace string) (context.Context, *Inventory) {
+	inventory := &Inventory{
+		owner:     owner,
+		namespace: namespace,
+		rendered:  map[string][]InventoryEntry{},
+	}
+
+	return context.WithValue(ctx, inventoryContextKey{}, inventory), inventory
+}
+
+func recordRendered(ctx context.Context, componentName string, resMap resmap.ResMap) {
+	inventory, found := ctx.Value(inventoryContextKey{}).(*Inventory)
+	if !found {
+		return
+	}
+
+	inventory.mu.Lock()
+	defer inventory.mu.Unlock()
+
+	for _, res := range resMap.Resources() {
+		resGvk := res.GetGvk()
+		inventory.rendered[componentName] = append(inventory.rendered[componentName], InventoryEntry{
+			Group:     resGvk.Group,
+			Version:   resGvk.Version,
+			Kind:      resGvk.Kind,
+			Namespace: res.GetNamespace(),
+			Name:      res.GetName(),
+		})
+	}
+}
+
+// Prune deletes resources labelled with any of the recorded component labels which were not rendered during
+// the reconciliation. Only resources controlled by the owner are deleted, and the same exemptions as for disabled
+// components apply, i.e. CRDs and resources shared with other components are kept. Rendered resources are stored
+// in the inventory ConfigMap of each component label, so kinds which are not rendered anymore are pruned as well.
+// Components whose rendered resources match their stored inventory are skipped, and resources are only listed
+// in namespaces they were rendered to, so unchanged components do not query the API server on every reconciliation.
+// When the context carries a Plan, deletions are only recorded in it and inventories are left untouched.
+func (i *Inventory) Prune(ctx context.Context, cli client.Client) error {
+	i.mu.Lock()
+	rendered := make(map[string][]InventoryEntry, len(i.rendered))
+	for componentName, entries := range i.rendered {
+		rendered[componentName] = append([]InventoryEntry(nil), entries...)
+	}
+	i.mu.Unlock()
+
+	var multiErr *multierror.Error
+	for componentName, entries := range rendered {
+		if err := i.prune(ctx, cli, componentName, entries); err != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("failed pruning resources of %s: %w", componentName, err))
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
+func (i *Inventory) prune(ctx context.Context, cli client.Client, componentName string, entries []InventoryEntry) error {
+	log := logf.FromContext(ctx)
+
+	plan, planning := planOf(ctx)
+	if planning {
+		cli = plan.client(cli, componentName)
+	}
+
+	inventoryConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "opendatahub-inventory-" + componentName,
+			Namespace: i.namespace,
+		},
+	}
+	previous, err := loadInventory(ctx, cli, inventoryConfigMap)
+	if err != nil {
+		return err
+	}
+
+	entries = normalized(entries)
+	if inventoryConfigMap.ResourceVersion != "" && slices.Equal(entries, previous) {
+		return nil
+	}
+
+	renderedKeys := make(map[objectKey]struct{}, len(entries))
+	for _, entry := range entries {
+		renderedKeys[keyOf(cli, entry)] = struct{}{}
+	}
+
+	var multiErr *multierror.Error
+	for _, kind := range kindsOf(entries, previous) {
+		for _, namespace := range namespacesOf(cli, kind, entries, previous) {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+			listOpts := []client.ListOption{client.MatchingLabels{labels.ODH.Component(componentName): "true"}}
+			if namespace != "" {
+				listOpts = append(listOpts, client.InNamespace(namespace))
+			}
+			if err := cli.List(ctx, list, listOpts...); err != nil {
+				if meta.IsNoMatchError(err) {
+					break
+				}
+				multiErr = multierror.Append(multiErr, fmt.Errorf("failed listing %s: %w", kind.Kind, err))
+
+				continue
+			}
+
+			for idx := range list.Items {
+				found := &list.Items[idx]
+				key := objectKey{group: kind.Group, kind: kind.Kind, namespace: found.GetNamespace(), name: found.GetName()}
+				if _, isRendered := renderedKeys[key]; isRendered || !i.isPrunable(found, componentName) {
+					continue
+				}
+
+				log.Info("pruning resource not present in manifests anymore", "kind", kind.Kind, "namespace", found.GetNamespace(), "name", found.GetName())
+				if err := cli.Delete(ctx, found); client.IgnoreNotFound(err) != nil {
+					multiErr = multierror.Append(multiErr, fmt.Errorf("failed deleting %s %s/%s: %w", kind.Kind, found.GetNamespace(), found.GetName(), err))
+				}
+			}
+		}
+	}
+
+	// previous inventory is kept when pruning failed, so kinds dropped from manifests are tried again
+	if multiErr.ErrorOrNil() != nil || planning {
+		return multiErr.ErrorOrNil()
+	}
+
+	return i.saveInventory(ctx, cli, inventoryConfigMap, entries)
+}
+
+// isPrunable checks whether the resource is controlled by the owner of the inventory and it is not exempted
+// from deletion the same way as in handleDisabledComponent.
+func (i *Inventory) isPrunable(found *unstructured.Unstructured, componentName string) bool {
+	if found.GetKind() == "CustomResourceDefinition" || isSharedResource(getComponentCounter(found.GetLabels()), componentName) {
+		return false
+	}
+
+	controller := metav1.GetControllerOf(found)
+
+	return controller != nil && controller.UID == i.owner.GetUID()
+}
+
+// keyOf returns the key of the rendered resource, ignoring the namespace set on cluster-scoped resources.
+func keyOf(cli client.Client, entry InventoryEntry) objectKey {
+	key := objectKey{group: entry.Group, kind: entry.Kind, namespace: entry.Namespace, name: entry.Name}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(entry.groupVersionKind())
+	if namespaced, err := cli.IsObjectNamespaced(obj); err == nil && !namespaced {
+		key.namespace = ""
+	}
+
+	return key
+}
+
+// kindsOf returns kinds of rendered and previously recorded resources, preferring currently rendered API versions.
+func kindsOf(rendered, previous []InventoryEntry) []schema.GroupVersionKind {
+	kinds := map[schema.GroupKind]schema.GroupVersionKind{}
+	for _, entry := range previous {
+		kinds[entry.groupVersionKind().GroupKind()] = entry.groupVersionKind()
+	}
+	for _, entry := range rendered {
+		kinds[entry.groupVersionKind().GroupKind()] = entry.groupVersionKind()
+	}
+
+	result := make([]schema.GroupVersionKind, 0, len(kinds))
+	for _, kind := range kinds {
+		result = append(result, kind)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].String() < result[b].String()
+	})
+
+	return result
+}
+
+// namespacesOf returns namespaces of rendered and previously recorded resources of the kind, so they can be listed
+// without querying the whole cluster. An empty namespace is returned for cluster-scoped kinds, and when any of
+// the resources was rendered without a namespace.
+func namespacesOf(cli client.Client, kind schema.GroupVersionKind, rendered, previous []InventoryEntry) []string {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kind)
+	if namespaced, err := cli.IsObjectNamespaced(obj); err == nil && !namespaced {
+		return []string{""}
+	}
+
+	namespaces := map[string]struct{}{}
+	for _, entry := range append(append([]InventoryEntry(nil), rendered...), previous...) {
+		if entry.groupVersionKind().GroupKind() != kind.GroupKind() {
+			continue
+		}
+		if entry.Namespace == "" {
+			return []string{""}
+		}
+		namespaces[entry.Namespace] = struct{}{}
+	}
+
+	result := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		result = append(result, namespace)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func loadInventory(ctx context.Context, cli client.Client, inventoryConfigMap *corev1.ConfigMap) ([]InventoryEntry, error) {
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(inventoryConfigMap), inventoryConfigMap); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	var entries []InventoryEntry
+	if err := yaml.Unmarshal([]byte(inventoryConfigMap.Data[inventoryKey]), &entries); err != nil {
+		return nil, fmt.Errorf("failed reading inventory %s/%s: %w", inventoryConfigMap.Namespace, inventoryConfigMap.Name, err)
+	}
+
+	return entries, nil
+}
+
+// normalized returns sorted entries without duplicates, as they are stored in the inventory.
+func normalized(entries []InventoryEntry) []InventoryEntry {
+	// the same manifests can be rendered more than once during the reconciliation, e.g. monitoring ones
+	unique := map[InventoryEntry]struct{}{}
+	sorted := make([]InventoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if _, duplicate := unique[entry]; !duplicate {
+			unique[entry] = struct{}{}
+			sorted = append(sorted, entry)
+		}
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return fmt.Sprint(sorted[a]) < fmt.Sprint(sorted[b])
+	})
+
+	return sorted
+}
+
+func (i *Inventory) saveInventory(ctx context.Context, cli client.Client, inventoryConfigMap *corev1.ConfigMap, entries []InventoryEntry) error {
+	content, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, cli, inventoryConfigMap, func() error {
+		inventoryConfigMap.Data = map[string]string{inventoryKey: string(content)}
+
+		return controllerutil.SetControllerReference(i.owner, inventoryConfigMap, cli.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed storing inventory %s/%s: %w", inventoryConfigMap.Namespace, inventoryConfigMap.Name, err)
+	}
+
+	return nil
+}
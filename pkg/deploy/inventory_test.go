@@ -0,0 +1,215 @@
+package deploy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pruning resources dropped from manifests", func() {
+
+	const appNamespace = "app-ns"
+
+	var (
+		cli   client.Client
+		dsc   *dscv1.DataScienceCluster
+		lists int
+	)
+
+	manifests := func(resources map[string]string) string {
+		GinkgoHelper()
+		dir := GinkgoT().TempDir()
+
+		kustomization := "resources:\n"
+		for name, content := range resources {
+			Expect(os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0o600)).To(Succeed())
+			kustomization += "- " + name + ".yaml\n"
+		}
+		Expect(os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(kustomization), 0o600)).To(Succeed())
+
+		return dir
+	}
+
+	configMap := func(name string) string {
+		return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n"
+	}
+
+	service := func(name string) string {
+		return "apiVersion: v1\nkind: Service\nmetadata:\n  name: " + name + "\nspec:\n  ports:\n  - port: 8080\n"
+	}
+
+	deployComponent := func(ctx context.Context, manifestPath string) error {
+		componentCtx, inventory := deploy.WithInventory(ctx, dsc, appNamespace)
+		if err := deploy.DeployManifestsFromPath(componentCtx, cli, dsc, manifestPath, appNamespace, "component", true); err != nil {
+			return err
+		}
+
+		return inventory.Prune(componentCtx, cli)
+	}
+
+	exists := func(ctx context.Context, obj client.Object, name string) bool {
+		GinkgoHelper()
+		err := cli.Get(ctx, types.NamespacedName{Namespace: appNamespace, Name: name}, obj)
+		if k8serr.IsNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+
+		return true
+	}
+
+	labelledConfigMap := func(name string, componentLabels map[string]string, ownerUID types.UID) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: appNamespace, Labels: componentLabels}}
+		if ownerUID != "" {
+			cm.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: dscv1.GroupVersion.String(),
+				Kind:       "DataScienceCluster",
+				Name:       "other",
+				UID:        ownerUID,
+				Controller: ptr.To(true),
+			}}
+		}
+
+		return cm
+	}
+
+	BeforeEach(func() {
+		DeferCleanup(deploy.InvalidateRenderCache)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+
+		dsc = &dscv1.DataScienceCluster{
+			TypeMeta:   metav1.TypeMeta{APIVersion: dscv1.GroupVersion.String(), Kind: "DataScienceCluster"},
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", UID: "dsc-uid"},
+		}
+
+		lists = 0
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				List: func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					lists++
+
+					return cli.List(ctx, list, opts...)
+				},
+				// apply patches are not supported by the fake client, existing resources are kept as they are
+				Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if patch.Type() == types.ApplyPatchType {
+						return nil
+					}
+
+					return cli.Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+	})
+
+	It("should delete resources no longer rendered and record the inventory", func(ctx context.Context) {
+		// given
+		Expect(deployComponent(ctx, manifests(map[string]string{
+			"kept":    configMap("kept"),
+			"dropped": configMap("dropped"),
+		}))).To(Succeed())
+
+		// when
+		Expect(deployComponent(ctx, manifests(map[string]string{
+			"kept": configMap("kept"),
+		}))).To(Succeed())
+
+		// then
+		Expect(exists(ctx, &corev1.ConfigMap{}, "kept")).To(BeTrue())
+		Expect(exists(ctx, &corev1.ConfigMap{}, "dropped")).To(BeFalse())
+
+		inventory := &corev1.ConfigMap{}
+		Expect(exists(ctx, inventory, "opendatahub-inventory-component")).To(BeTrue())
+		Expect(inventory.Data["resources"]).To(ContainSubstring("name: kept"))
+		Expect(inventory.Data["resources"]).ToNot(ContainSubstring("name: dropped"))
+	})
+
+	It("should delete resources of kinds no longer rendered", func(ctx context.Context) {
+		// given
+		Expect(deployComponent(ctx, manifests(map[string]string{
+			"kept":    configMap("kept"),
+			"metrics": service("metrics"),
+		}))).To(Succeed())
+
+		// when
+		Expect(deployComponent(ctx, manifests(map[string]string{
+			"kept": configMap("kept"),
+		}))).To(Succeed())
+
+		// then
+		Expect(exists(ctx, &corev1.Service{}, "metrics")).To(BeFalse())
+	})
+
+	It("should keep shared resources and resources not controlled by the owner", func(ctx context.Context) {
+		// given
+		componentLabel := map[string]string{"app.opendatahub.io/component": "true"}
+		Expect(cli.Create(ctx, labelledConfigMap("shared",
+			map[string]string{"app.opendatahub.io/component": "true", "app.opendatahub.io/other": "true"}, dsc.UID))).To(Succeed())
+		Expect(cli.Create(ctx, labelledConfigMap("foreign", componentLabel, "other-uid"))).To(Succeed())
+		Expect(cli.Create(ctx, labelledConfigMap("unowned", componentLabel, ""))).To(Succeed())
+		Expect(cli.Create(ctx, labelledConfigMap("stale", componentLabel, dsc.UID))).To(Succeed())
+
+		// when
+		Expect(deployComponent(ctx, manifests(map[string]string{
+			"kept": configMap("kept"),
+		}))).To(Succeed())
+
+		// then
+		Expect(exists(ctx, &corev1.ConfigMap{}, "shared")).To(BeTrue())
+		Expect(exists(ctx, &corev1.ConfigMap{}, "foreign")).To(BeTrue())
+		Expect(exists(ctx, &corev1.ConfigMap{}, "unowned")).To(BeTrue())
+		Expect(exists(ctx, &corev1.ConfigMap{}, "stale")).To(BeFalse())
+	})
+
+	It("should not list resources when rendered resources did not change", func(ctx context.Context) {
+		// given
+		manifestPath := manifests(map[string]string{
+			"kept": configMap("kept"),
+		})
+		Expect(deployComponent(ctx, manifestPath)).To(Succeed())
+		lists = 0
+
+		// when
+		Expect(deployComponent(ctx, manifestPath)).To(Succeed())
+
+		// then
+		Expect(lists).To(BeZero())
+		Expect(exists(ctx, &corev1.ConfigMap{}, "kept")).To(BeTrue())
+	})
+
+	It("should only prune resources in namespaces the component was rendered to", func(ctx context.Context) {
+		// given
+		elsewhere := labelledConfigMap("elsewhere", map[string]string{"app.opendatahub.io/component": "true"}, dsc.UID)
+		elsewhere.Namespace = "other-ns"
+		Expect(cli.Create(ctx, elsewhere)).To(Succeed())
+
+		// when
+		Expect(deployComponent(ctx, manifests(map[string]string{
+			"kept": configMap("kept"),
+		}))).To(Succeed())
+
+		// then
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(elsewhere), &corev1.ConfigMap{})).To(Succeed())
+	})
+})
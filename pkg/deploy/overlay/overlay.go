@@ -0,0 +1,221 @@
+// Package overlay keeps in-memory edits of the manifests shipped with the operator, e.g. params.env with parameters
+// of the cluster. Files on disk are never modified: each edit is a named transformation, applied to the pristine file
+// whenever manifests are rendered. Setting a transformation again replaces its previous outcome, so parameters which
+// change back, e.g. NIM being disabled again, are reflected in the rendered manifests.
+package overlay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Transform returns the content of the file with the edit applied.
+type Transform func(content []byte) ([]byte, error)
+
+// Overlay holds transformations of files, applied in the order they were first set.
+type Overlay struct {
+	mu    sync.RWMutex
+	files map[string]*transforms
+}
+
+type transforms struct {
+	names  []string
+	byName map[string]Transform
+}
+
+// Manifests is the overlay of the manifests deployed by the operator, shared by all controllers, so edits made
+// by one of them, e.g. namespaces set in prometheus configs when DSCInitialization is reconciled, are rendered
+// by the others as well.
+var Manifests = New()
+
+func New() *Overlay {
+	return &Overlay{files: map[string]*transforms{}}
+}
+
+// Set adds the named transformation of the file, or replaces the one of the same name keeping its position.
+// The transformation is kept only when all transformations of the file apply to its content on disk.
+func (o *Overlay) Set(path, name string, transform Transform) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	path = absolute(path)
+	file, found := o.files[path]
+	if !found {
+		file = &transforms{byName: map[string]Transform{}}
+		o.files[path] = file
+	}
+	previous, replaced := file.byName[name]
+	if !replaced {
+		file.names = append(file.names, name)
+	}
+	file.byName[name] = transform
+
+	if _, err := o.readFile(path); err != nil {
+		if replaced {
+			file.byName[name] = previous
+		} else {
+			o.delete(path, name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the named transformation of the file, if it was set.
+func (o *Overlay) Delete(path, name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.delete(absolute(path), name)
+}
+
+func (o *Overlay) delete(path, name string) {
+	file, found := o.files[path]
+	if !found {
+		return
+	}
+	if _, found := file.byName[name]; !found {
+		return
+	}
+
+	delete(file.byName, name)
+	for i := range file.names {
+		if file.names[i] == name {
+			file.names = append(file.names[:i], file.names[i+1:]...)
+
+			break
+		}
+	}
+	if len(file.names) == 0 {
+		delete(o.files, path)
+	}
+}
+
+// ReadFile returns the content of the file on disk with all its transformations applied.
+func (o *Overlay) ReadFile(path string) ([]byte, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.readFile(absolute(path))
+}
+
+// absolute identifies files by their absolute paths, which kustomize reads them by.
+func absolute(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+
+	return filepath.Clean(path)
+}
+
+func (o *Overlay) readFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, found := o.files[path]
+	if !found {
+		return content, nil
+	}
+
+	for _, name := range file.names {
+		if content, err = file.byName[name](content); err != nil {
+			return nil, fmt.Errorf("failed applying %s to %s: %w", name, path, err)
+		}
+	}
+
+	return content, nil
+}
+
+// Snapshot applies transformations of all files, skipping files which are not present on disk,
+// e.g. params.env of manifests downloaded for DevFlags which were removed since.
+func (o *Overlay) Snapshot() (*Snapshot, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	snapshot := &Snapshot{files: make(map[string][]byte, len(o.files))}
+	for path := range o.files {
+		content, err := o.readFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+		snapshot.files[path] = content
+	}
+
+	return snapshot, nil
+}
+
+// Snapshot holds overlaid files with their transformations applied.
+type Snapshot struct {
+	files map[string][]byte
+}
+
+// Digest identifies paths and contents of the overlaid files.
+func (s *Snapshot) Digest() string {
+	paths := make([]string, 0, len(s.files))
+	for path := range s.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	hasher := sha256.New()
+	for _, path := range paths {
+		hasher.Write([]byte(path))
+		hasher.Write([]byte{0})
+		hasher.Write(s.files[path])
+		hasher.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// FileSystem returns an in-memory file system with the overlaid files layered over the disk,
+// so kustomize reads the overlaid content of the files and everything else from the disk.
+func (s *Snapshot) FileSystem() (filesys.FileSystem, error) {
+	inMemory := filesys.MakeFsInMemory()
+	for path, content := range s.files {
+		if err := inMemory.WriteFile(path, content); err != nil {
+			return nil, fmt.Errorf("failed adding %s to the overlay: %w", path, err)
+		}
+	}
+
+	return &layeredFileSystem{FileSystem: filesys.MakeFsOnDisk(), overlay: inMemory}, nil
+}
+
+// layeredFileSystem reads files present in the overlay from it, all other operations go to the disk.
+type layeredFileSystem struct {
+	filesys.FileSystem
+	overlay filesys.FileSystem
+}
+
+func (l *layeredFileSystem) Open(path string) (filesys.File, error) {
+	if l.overlay.Exists(path) && !l.overlay.IsDir(path) {
+		return l.overlay.Open(path)
+	}
+
+	return l.FileSystem.Open(path)
+}
+
+func (l *layeredFileSystem) ReadFile(path string) ([]byte, error) {
+	if l.overlay.Exists(path) && !l.overlay.IsDir(path) {
+		return l.overlay.ReadFile(path)
+	}
+
+	return l.FileSystem.ReadFile(path)
+}
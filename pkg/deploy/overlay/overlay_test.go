@@ -0,0 +1,110 @@
+package overlay_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy/overlay"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Overlaying manifests", func() {
+
+	var (
+		manifests *overlay.Overlay
+		dir       string
+		file      string
+	)
+
+	replace := func(oldValue, newValue string) overlay.Transform {
+		return func(content []byte) ([]byte, error) {
+			return []byte(strings.ReplaceAll(string(content), oldValue, newValue)), nil
+		}
+	}
+
+	BeforeEach(func() {
+		manifests = overlay.New()
+		dir = GinkgoT().TempDir()
+		file = filepath.Join(dir, "params.env")
+		Expect(os.WriteFile(file, []byte("namespace=<namespace>\nstate=<state>\n"), 0o600)).To(Succeed())
+	})
+
+	It("should apply transformations without changing the file on disk", func() {
+		// when
+		Expect(manifests.Set(file, "namespace", replace("<namespace>", "app-ns"))).To(Succeed())
+		Expect(manifests.Set(file, "state", replace("<state>", "Managed"))).To(Succeed())
+
+		// then
+		Expect(manifests.ReadFile(file)).To(BeEquivalentTo("namespace=app-ns\nstate=Managed\n"))
+		Expect(os.ReadFile(file)).To(BeEquivalentTo("namespace=<namespace>\nstate=<state>\n"))
+	})
+
+	It("should replace outcome of the transformation set again", func() {
+		// given
+		Expect(manifests.Set(file, "state", replace("<state>", "Managed"))).To(Succeed())
+
+		// when
+		Expect(manifests.Set(file, "state", replace("<state>", "Removed"))).To(Succeed())
+
+		// then
+		Expect(manifests.ReadFile(file)).To(BeEquivalentTo("namespace=<namespace>\nstate=Removed\n"))
+	})
+
+	It("should keep previous transformation when the new one fails", func() {
+		// given
+		Expect(manifests.Set(file, "state", replace("<state>", "Managed"))).To(Succeed())
+
+		// when
+		err := manifests.Set(file, "state", func([]byte) ([]byte, error) {
+			return nil, errors.New("invalid content")
+		})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("invalid content")))
+		Expect(manifests.ReadFile(file)).To(BeEquivalentTo("namespace=<namespace>\nstate=Managed\n"))
+	})
+
+	It("should not set transformations of missing files", func() {
+		// when
+		err := manifests.Set(filepath.Join(dir, "missing.env"), "state", replace("<state>", "Managed"))
+
+		// then
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should read overlaid files from memory and other files from disk", func() {
+		// given
+		other := filepath.Join(dir, "other.yaml")
+		Expect(os.WriteFile(other, []byte("value: <state>\n"), 0o600)).To(Succeed())
+		Expect(manifests.Set(file, "state", replace("<state>", "Managed"))).To(Succeed())
+
+		// when
+		snapshot, err := manifests.Snapshot()
+		Expect(err).ToNot(HaveOccurred())
+		fSys, err := snapshot.FileSystem()
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(fSys.ReadFile(file)).To(BeEquivalentTo("namespace=<namespace>\nstate=Managed\n"))
+		Expect(fSys.ReadFile(other)).To(BeEquivalentTo("value: <state>\n"))
+	})
+
+	It("should change digest with the overlaid content", func() {
+		// given
+		Expect(manifests.Set(file, "state", replace("<state>", "Managed"))).To(Succeed())
+		managed, err := manifests.Snapshot()
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		Expect(manifests.Set(file, "state", replace("<state>", "Removed"))).To(Succeed())
+		removed, err := manifests.Snapshot()
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(removed.Digest()).ToNot(Equal(managed.Digest()))
+	})
+})
@@ -0,0 +1,57 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+)
+
+// OverrideManifestPath is the directory to which manifests set in DevFlags are installed. Manifests shipped with the
+// operator in DefaultManifestPath are never modified, so they are deployed again as soon as the override is removed.
+var OverrideManifestPath = overrideManifestPath()
+
+func overrideManifestPath() string {
+	if dir := os.Getenv("MANIFESTS_OVERRIDE_PATH"); dir != "" {
+		return dir
+	}
+
+	return filepath.Join(os.TempDir(), "opendatahub-manifests-overrides")
+}
+
+// ManifestsDir returns the directory holding the manifests installed under dir, e.g. "kserve": the ones downloaded
+// for the DevFlags override when it is set and was installed, otherwise the ones shipped with the operator.
+func ManifestsDir(dir string, override *components.ManifestsConfig) string {
+	if overrideDir, overridden := overriddenManifestsDir(dir, override); overridden {
+		return overrideDir
+	}
+
+	return filepath.Join(DefaultManifestPath, dir)
+}
+
+// ManifestsPath returns the kustomize directory of the manifests installed under dir, which is the source path
+// of the DevFlags override when it is set and was installed, otherwise the default path, e.g. "overlays/odh".
+func ManifestsPath(dir, defaultPath string, override *components.ManifestsConfig) string {
+	if overrideDir, overridden := overriddenManifestsDir(dir, override); overridden {
+		if override.SourcePath != "" {
+			defaultPath = override.SourcePath
+		}
+
+		return filepath.Join(overrideDir, defaultPath)
+	}
+
+	return filepath.Join(DefaultManifestPath, dir, defaultPath)
+}
+
+func overriddenManifestsDir(dir string, override *components.ManifestsConfig) (string, bool) {
+	if override == nil {
+		return "", false
+	}
+
+	overrideDir := filepath.Join(OverrideManifestPath, dir)
+	if info, err := os.Stat(overrideDir); err != nil || !info.IsDir() {
+		return "", false
+	}
+
+	return overrideDir, true
+}
@@ -0,0 +1,55 @@
+package deploy_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolving manifests paths", func() {
+
+	var manifestsDir, overridesDir string
+
+	BeforeEach(func() {
+		manifestsDir, overridesDir = GinkgoT().TempDir(), GinkgoT().TempDir()
+		originalManifestPath, originalOverridePath := deploy.DefaultManifestPath, deploy.OverrideManifestPath
+		deploy.DefaultManifestPath, deploy.OverrideManifestPath = manifestsDir, overridesDir
+		DeferCleanup(func() {
+			deploy.DefaultManifestPath, deploy.OverrideManifestPath = originalManifestPath, originalOverridePath
+		})
+	})
+
+	It("should use shipped manifests when there is no override", func() {
+		// given
+		Expect(os.MkdirAll(filepath.Join(overridesDir, "component"), os.ModePerm)).To(Succeed())
+
+		// then
+		Expect(deploy.ManifestsPath("component", "overlays/odh", nil)).To(Equal(filepath.Join(manifestsDir, "component", "overlays/odh")))
+		Expect(deploy.ManifestsDir("component", nil)).To(Equal(filepath.Join(manifestsDir, "component")))
+	})
+
+	It("should use shipped manifests until the override is installed", func() {
+		// given
+		override := &components.ManifestsConfig{SourcePath: "overlays/dev"}
+
+		// then
+		Expect(deploy.ManifestsPath("component", "overlays/odh", override)).To(Equal(filepath.Join(manifestsDir, "component", "overlays/odh")))
+	})
+
+	It("should use installed override and its source path", func() {
+		// given
+		Expect(os.MkdirAll(filepath.Join(overridesDir, "component"), os.ModePerm)).To(Succeed())
+
+		// then
+		Expect(deploy.ManifestsPath("component", "overlays/odh", &components.ManifestsConfig{SourcePath: "overlays/dev"})).
+			To(Equal(filepath.Join(overridesDir, "component", "overlays/dev")))
+		Expect(deploy.ManifestsPath("component", "overlays/odh", &components.ManifestsConfig{})).
+			To(Equal(filepath.Join(overridesDir, "component", "overlays/odh")))
+		Expect(deploy.ManifestsDir("component", &components.ManifestsConfig{})).To(Equal(filepath.Join(overridesDir, "component")))
+	})
+})
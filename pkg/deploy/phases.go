@@ -0,0 +1,156 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/resource"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// applyPhase groups resources which are applied together, before resources of the following phases.
+type applyPhase int
+
+const (
+	phaseNamespaces applyPhase = iota
+	phaseCRDs
+	phaseRBAC
+	phaseConfig
+	phaseWorkloads
+	phaseCustomResources
+)
+
+var applyPhaseNames = map[applyPhase]string{
+	phaseNamespaces:      "namespaces",
+	phaseCRDs:            "custom resource definitions",
+	phaseRBAC:            "RBAC resources",
+	phaseConfig:          "configuration",
+	phaseWorkloads:       "workloads",
+	phaseCustomResources: "custom resources",
+}
+
+func (p applyPhase) String() string {
+	return applyPhaseNames[p]
+}
+
+// CRDEstablishedTimeout limits how long custom resources wait for their CustomResourceDefinitions to be established.
+var CRDEstablishedTimeout = time.Minute
+
+// groupByPhase sorts resources into phases, keeping the order in which kustomize emitted them within each phase.
+// Kinds defined by rendered CustomResourceDefinitions or unknown to the scheme of the client are custom resources.
+func groupByPhase(cli client.Client, resources []*resource.Resource) map[applyPhase][]*resource.Resource {
+	customKinds := map[schema.GroupKind]struct{}{}
+	for _, res := range resources {
+		if res.GetKind() != gvk.CustomResourceDefinition.Kind {
+			continue
+		}
+		crd, err := res.Map()
+		if err != nil {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(crd, "spec", "group")
+		kind, _, _ := unstructured.NestedString(crd, "spec", "names", "kind")
+		customKinds[schema.GroupKind{Group: group, Kind: kind}] = struct{}{}
+	}
+
+	phases := map[applyPhase][]*resource.Resource{}
+	for _, res := range resources {
+		resGvk := res.GetGvk()
+		objGvk := schema.GroupVersionKind{Group: resGvk.Group, Version: resGvk.Version, Kind: resGvk.Kind}
+
+		phase := phaseWorkloads
+		switch {
+		case objGvk.Kind == "Namespace" && objGvk.Group == "":
+			phase = phaseNamespaces
+		case objGvk.GroupKind() == gvk.CustomResourceDefinition.GroupKind():
+			phase = phaseCRDs
+		case objGvk.Kind == "ServiceAccount" && objGvk.Group == "",
+			objGvk.Group == "rbac.authorization.k8s.io":
+			phase = phaseRBAC
+		case (objGvk.Kind == "ConfigMap" || objGvk.Kind == "Secret") && objGvk.Group == "":
+			phase = phaseConfig
+		default:
+			if _, isCustom := customKinds[objGvk.GroupKind()]; isCustom || !cli.Scheme().Recognizes(objGvk) {
+				phase = phaseCustomResources
+			}
+		}
+		phases[phase] = append(phases[phase], res)
+	}
+
+	return phases
+}
+
+// manageResources applies resources of a single phase. Resources failing with transient errors do not prevent
+// the remaining ones from being applied, but the phase fails, so the reconciliation is requeued and applies them again
+// instead of waiting for them.
+func manageResources(ctx context.Context, cli client.Client, phase applyPhase, resources []*resource.Resource,
+	owner metav1.Object, applicationNamespace, componentName string, enabled bool,
+) error {
+	var multiErr *multierror.Error
+	for _, res := range resources {
+		err := manageResource(ctx, cli, res, owner, applicationNamespace, componentName, enabled)
+		if err == nil {
+			continue
+		}
+		if !isTransient(err) {
+			return err
+		}
+		multiErr = multierror.Append(multiErr, fmt.Errorf("%s %s/%s: %w", res.GetKind(), res.GetNamespace(), res.GetName(), err))
+	}
+
+	if multiErr != nil {
+		return fmt.Errorf("%s are not applied yet, will be retried: %w", phase, multiErr)
+	}
+
+	return nil
+}
+
+// isTransient checks if the error is likely to disappear when the resource is applied again, e.g. when the kind is not
+// served yet or the API server is temporarily unable to handle the request.
+func isTransient(err error) bool {
+	return meta.IsNoMatchError(err) ||
+		k8serr.IsConflict(err) ||
+		k8serr.IsServerTimeout(err) ||
+		k8serr.IsTimeout(err) ||
+		k8serr.IsTooManyRequests(err) ||
+		k8serr.IsServiceUnavailable(err)
+}
+
+// waitForCRDsEstablished waits until the rendered CustomResourceDefinitions are served. The RESTMapper of the client
+// does not need to be reset afterwards, as the dynamic one used by controller-runtime reloads the group of a kind
+// it cannot match, so custom resources of newly established kinds can be mapped.
+func waitForCRDsEstablished(ctx context.Context, cli client.Client, crds []*resource.Resource) error {
+	for _, crd := range crds {
+		err := wait.PollUntilContextTimeout(ctx, time.Second, CRDEstablishedTimeout, true, func(ctx context.Context) (bool, error) {
+			found := &unstructured.Unstructured{}
+			found.SetGroupVersionKind(gvk.CustomResourceDefinition)
+			if err := cli.Get(ctx, client.ObjectKey{Name: crd.GetName()}, found); err != nil {
+				return false, client.IgnoreNotFound(err)
+			}
+
+			conditions, _, _ := unstructured.NestedSlice(found.Object, "status", "conditions")
+			for _, item := range conditions {
+				if condition, isMap := item.(map[string]any); isMap && condition["type"] == "Established" {
+					return condition["status"] == string(metav1.ConditionTrue), nil
+				}
+			}
+
+			return false, nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed waiting for CustomResourceDefinition %s to be established: %w", crd.GetName(), err)
+		}
+	}
+
+	return nil
+}
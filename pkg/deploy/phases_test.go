@@ -0,0 +1,249 @@
+package deploy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	featureresource "github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Applying manifests in phases", func() {
+
+	const appNamespace = "app-ns"
+
+	var (
+		cli            client.Client
+		dsc            *dscv1.DataScienceCluster
+		served         bool
+		establishCRDs  bool
+		createdKinds   []string
+		failingCreates int
+	)
+
+	manifests := func(resources map[string]string) string {
+		GinkgoHelper()
+		dir := GinkgoT().TempDir()
+
+		kustomization := "resources:\n"
+		for name, content := range resources {
+			Expect(os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0o600)).To(Succeed())
+			kustomization += "- " + name + ".yaml\n"
+		}
+		Expect(os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(kustomization), 0o600)).To(Succeed())
+
+		return dir
+	}
+
+	const (
+		widgetCRD = "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: widgets.example.com\n" +
+			"spec:\n  group: example.com\n  names:\n    kind: Widget\n    plural: widgets\n  scope: Namespaced\n"
+		widget     = "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: widget\n"
+		namespace  = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: other-ns\n"
+		role       = "apiVersion: rbac.authorization.k8s.io/v1\nkind: Role\nmetadata:\n  name: role\n"
+		configMap  = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"
+		deployment = "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: manager\n"
+	)
+
+	isWidget := func(obj client.Object) bool {
+		u, isUnstructured := obj.(*unstructured.Unstructured)
+
+		return isUnstructured && u.GetKind() == "Widget"
+	}
+
+	noKindMatch := &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "example.com", Kind: "Widget"}, SearchedVersions: []string{"v1"}}
+
+	BeforeEach(func() {
+		DeferCleanup(deploy.InvalidateRenderCache)
+		originalTimeout := deploy.CRDEstablishedTimeout
+		deploy.CRDEstablishedTimeout = 1500 * time.Millisecond
+		DeferCleanup(func() {
+			deploy.CRDEstablishedTimeout = originalTimeout
+		})
+
+		served, establishCRDs, createdKinds, failingCreates = false, true, nil, 0
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+
+		dsc = &dscv1.DataScienceCluster{
+			TypeMeta:   metav1.TypeMeta{APIVersion: dscv1.GroupVersion.String(), Kind: "DataScienceCluster"},
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", UID: "dsc-uid"},
+		}
+
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// Widget kind is served only once its CRD is established
+				Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if isWidget(obj) && !served {
+						return noKindMatch
+					}
+
+					return cli.Get(ctx, key, obj, opts...)
+				},
+				Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if isWidget(obj) {
+						if !served {
+							return noKindMatch
+						}
+						if failingCreates > 0 {
+							failingCreates--
+
+							return noKindMatch
+						}
+					}
+
+					createOptions := &client.CreateOptions{}
+					createOptions.ApplyOptions(opts)
+					if len(createOptions.DryRun) > 0 {
+						return cli.Create(ctx, obj, opts...)
+					}
+
+					if u, isUnstructured := obj.(*unstructured.Unstructured); isUnstructured && u.GetKind() == gvk.CustomResourceDefinition.Kind && establishCRDs {
+						Expect(unstructured.SetNestedSlice(u.Object, []any{
+							map[string]any{"type": "Established", "status": "True"},
+						}, "status", "conditions")).To(Succeed())
+						served = true
+					}
+					createdKinds = append(createdKinds, obj.GetObjectKind().GroupVersionKind().Kind)
+
+					return cli.Create(ctx, obj, opts...)
+				},
+				// fake client does not support server-side apply, existing resources are left as they are
+				Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if patch.Type() == types.ApplyPatchType {
+						return nil
+					}
+
+					return cli.Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+	})
+
+	It("should create custom resources after their CRDs are established", func(ctx context.Context) {
+		// when
+		err := deploy.DeployManifestsFromPath(ctx, cli, dsc, manifests(map[string]string{
+			"widget":     widget,
+			"deployment": deployment,
+			"config":     configMap,
+			"role":       role,
+			"crd":        widgetCRD,
+			"namespace":  namespace,
+		}), appNamespace, "component", true)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdKinds).To(Equal([]string{"Namespace", "CustomResourceDefinition", "Role", "ConfigMap", "Deployment", "Widget"}))
+
+		created := &unstructured.Unstructured{}
+		created.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+		Expect(cli.Get(ctx, types.NamespacedName{Namespace: appNamespace, Name: "widget"}, created)).To(Succeed())
+	})
+
+	It("should apply resources which failed with transient errors when deployed again", func(ctx context.Context) {
+		// given
+		failingCreates = 1
+		manifestsPath := manifests(map[string]string{
+			"crd":    widgetCRD,
+			"widget": widget,
+		})
+		Expect(deploy.DeployManifestsFromPath(ctx, cli, dsc, manifestsPath, appNamespace, "component", true)).
+			To(MatchError(ContainSubstring("custom resources are not applied yet, will be retried")))
+
+		// when
+		err := deploy.DeployManifestsFromPath(ctx, cli, dsc, manifestsPath, appNamespace, "component", true)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdKinds).To(Equal([]string{"CustomResourceDefinition", "Widget"}))
+	})
+
+	It("should not apply custom resources when their CRD is not established", func(ctx context.Context) {
+		// given
+		establishCRDs = false
+
+		// when
+		err := deploy.DeployManifestsFromPath(ctx, cli, dsc, manifests(map[string]string{
+			"crd":    widgetCRD,
+			"widget": widget,
+			"config": configMap,
+		}), appNamespace, "component", true)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("CustomResourceDefinition widgets.example.com to be established")))
+		Expect(createdKinds).To(Equal([]string{"CustomResourceDefinition"}))
+	})
+
+	It("should plan custom resources of CRDs which would be created", func(ctx context.Context) {
+		// given
+		planCtx, plan := deploy.WithPlan(ctx)
+
+		// when
+		err := deploy.DeployManifestsFromPath(planCtx, cli, dsc, manifests(map[string]string{
+			"crd":    widgetCRD,
+			"widget": widget,
+		}), appNamespace, "component", true)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdKinds).To(BeEmpty())
+		Expect(plan.Changes()["component"]).To(ConsistOf(
+			And(HaveField("Action", featureresource.ActionCreate), HaveField("GroupVersionKind.Kind", "CustomResourceDefinition")),
+			And(HaveField("Action", featureresource.ActionCreate), HaveField("GroupVersionKind.Kind", "Widget")),
+		))
+	})
+
+	It("should report kinds which are not served for enabled components", func(ctx context.Context) {
+		// when
+		err := deploy.DeployManifestsFromPath(ctx, cli, dsc, manifests(map[string]string{
+			"widget": widget,
+			"config": configMap,
+		}), appNamespace, "component", true)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("custom resources are not applied yet")))
+		Expect(meta.IsNoMatchError(err)).To(BeTrue())
+		Expect(createdKinds).To(Equal([]string{"ConfigMap"}))
+	})
+
+	It("should ignore kinds which are not served for disabled components", func(ctx context.Context) {
+		// given
+		Expect(cli.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: appNamespace,
+			Labels:    map[string]string{"app.opendatahub.io/component": "true"},
+		}})).To(Succeed())
+
+		// when
+		err := deploy.DeployManifestsFromPath(ctx, cli, dsc, manifests(map[string]string{
+			"widget": widget,
+			"config": configMap,
+		}), appNamespace, "component", false)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cli.Get(ctx, types.NamespacedName{Namespace: appNamespace, Name: "config"}, &corev1.ConfigMap{})).ToNot(Succeed())
+	})
+})
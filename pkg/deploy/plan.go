@@ -0,0 +1,206 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/kustomize/api/resource"
+
+	featureresource "github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+)
+
+// Plan collects changes which DeployManifestsFromPath and Inventory.Prune would introduce to the cluster,
+// grouped by the app.opendatahub.io/<component> label the resources are deployed with.
+//
+// When the context carries a Plan, resources go through the same steps as when they are deployed, i.e. the allowlist
+// remover, label retention, conflict resolution and deletion of resources of disabled components, but all writes are
+// sent as server-side dry-run requests and their outcome is recorded instead of being persisted.
+type Plan struct {
+	mu      sync.Mutex
+	changes map[string][]featureresource.Change
+	index   map[string]map[objectKey]int
+}
+
+type planContextKey struct{}
+
+// WithPlan returns a context carrying a new Plan, which switches DeployManifestsFromPath to dry-run.
+func WithPlan(ctx context.Context) (context.Context, *Plan) {
+	plan := &Plan{
+		changes: map[string][]featureresource.Change{},
+		index:   map[string]map[objectKey]int{},
+	}
+
+	return context.WithValue(ctx, planContextKey{}, plan), plan
+}
+
+func planOf(ctx context.Context) (*Plan, bool) {
+	plan, found := ctx.Value(planContextKey{}).(*Plan)
+
+	return plan, found
+}
+
+// Changes returns recorded changes of each component label in the order they were planned.
+// Manifests deployed more than once during the reconciliation are reported with the outcome of the last deployment.
+func (p *Plan) Changes() map[string][]featureresource.Change {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	changes := make(map[string][]featureresource.Change, len(p.changes))
+	for componentName, componentChanges := range p.changes {
+		changes[componentName] = append([]featureresource.Change(nil), componentChanges...)
+	}
+
+	return changes
+}
+
+func (p *Plan) record(componentName string, change featureresource.Change) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := objectKey{
+		group:     change.GroupVersionKind.Group,
+		kind:      change.GroupVersionKind.Kind,
+		namespace: change.Namespace,
+		name:      change.Name,
+	}
+	if p.index[componentName] == nil {
+		p.index[componentName] = map[objectKey]int{}
+	}
+
+	if idx, recorded := p.index[componentName][key]; recorded {
+		p.changes[componentName][idx] = change
+
+		return
+	}
+
+	p.index[componentName][key] = len(p.changes[componentName])
+	p.changes[componentName] = append(p.changes[componentName], change)
+}
+
+// recordUnserved records creation of the resource whose kind is not served yet, i.e. it is defined by a CRD which
+// would be created as well.
+func (p *Plan) recordUnserved(componentName string, res *resource.Resource) {
+	resGvk := res.GetGvk()
+	p.record(componentName, featureresource.Change{
+		Action:           featureresource.ActionCreate,
+		GroupVersionKind: schema.GroupVersionKind{Group: resGvk.Group, Version: resGvk.Version, Kind: resGvk.Kind},
+		Namespace:        res.GetNamespace(),
+		Name:             res.GetName(),
+	})
+}
+
+// client returns a client which records writes to resources of the component as changes of the plan.
+func (p *Plan) client(cli client.Client, componentName string) client.Client {
+	return &planningClient{Client: cli, plan: p, componentName: componentName}
+}
+
+// planningClient sends all writes as dry-run requests and records their outcome in the plan.
+type planningClient struct {
+	client.Client
+	plan          *Plan
+	componentName string
+}
+
+func (c *planningClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, append(opts, client.DryRunAll)...); err != nil {
+		return err
+	}
+
+	created, err := c.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	// the whole object would be created as rendered, so it is not repeated in the diff
+	c.plan.record(c.componentName, featureresource.Change{
+		Action:           featureresource.ActionCreate,
+		GroupVersionKind: created.GroupVersionKind(),
+		Namespace:        created.GetNamespace(),
+		Name:             created.GetName(),
+	})
+
+	return nil
+}
+
+func (c *planningClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.recordPatch(obj, func() error {
+		return c.Client.Update(ctx, obj, append(opts, client.DryRunAll)...)
+	})
+}
+
+func (c *planningClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.recordPatch(obj, func() error {
+		return c.Client.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+	})
+}
+
+func (c *planningClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, append(opts, client.DryRunAll)...); err != nil {
+		return err
+	}
+
+	deleted, err := c.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	c.plan.record(c.componentName, featureresource.Change{
+		Action:           featureresource.ActionDelete,
+		GroupVersionKind: deleted.GroupVersionKind(),
+		Namespace:        deleted.GetNamespace(),
+		Name:             deleted.GetName(),
+	})
+
+	return nil
+}
+
+// recordPatch compares the object before and after the dry-run write, which updates it with the response of the API server.
+func (c *planningClient) recordPatch(obj client.Object, write func() error) error {
+	current, err := c.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	if err := write(); err != nil {
+		return err
+	}
+
+	patched, err := c.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	change, err := featureresource.NewChange(current, current, patched)
+	if err != nil {
+		return err
+	}
+	c.plan.record(c.componentName, change)
+
+	return nil
+}
+
+func (c *planningClient) toUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	if u, isUnstructured := obj.(*unstructured.Unstructured); isUnstructured {
+		return u.DeepCopy(), nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	u := &unstructured.Unstructured{Object: content}
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	u.SetGroupVersionKind(gvk)
+
+	return u, nil
+}
@@ -0,0 +1,194 @@
+package deploy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	featureresource "github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Planning changes of component manifests", func() {
+
+	const appNamespace = "app-ns"
+
+	var (
+		cli client.Client
+		dsc *dscv1.DataScienceCluster
+	)
+
+	manifests := func(resources map[string]string) string {
+		GinkgoHelper()
+		dir := GinkgoT().TempDir()
+
+		kustomization := "resources:\n"
+		for name, content := range resources {
+			Expect(os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0o600)).To(Succeed())
+			kustomization += "- " + name + ".yaml\n"
+		}
+		Expect(os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(kustomization), 0o600)).To(Succeed())
+
+		return dir
+	}
+
+	configMap := func(name string) string {
+		return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n"
+	}
+
+	deployment := func(name, image string) string {
+		return "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: " + name + "\nspec:\n  replicas: 1\n" +
+			"  template:\n    spec:\n      containers:\n      - name: manager\n        image: " + image + "\n"
+	}
+
+	deployComponent := func(ctx context.Context, manifestPath string) error {
+		componentCtx, inventory := deploy.WithInventory(ctx, dsc, appNamespace)
+		if err := deploy.DeployManifestsFromPath(componentCtx, cli, dsc, manifestPath, appNamespace, "component", true); err != nil {
+			return err
+		}
+
+		return inventory.Prune(componentCtx, cli)
+	}
+
+	exists := func(ctx context.Context, obj client.Object, name string) bool {
+		GinkgoHelper()
+		err := cli.Get(ctx, types.NamespacedName{Namespace: appNamespace, Name: name}, obj)
+		if k8serr.IsNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+
+		return true
+	}
+
+	BeforeEach(func() {
+		DeferCleanup(deploy.InvalidateRenderCache)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+
+		dsc = &dscv1.DataScienceCluster{
+			TypeMeta:   metav1.TypeMeta{APIVersion: dscv1.GroupVersion.String(), Kind: "DataScienceCluster"},
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", UID: "dsc-uid"},
+		}
+
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// apply patches are not supported by the fake client, existing resources are kept as they are and
+				// dry-run responses are approximated by merging the applied configuration into the object
+				Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if patch.Type() != types.ApplyPatchType {
+						return cli.Patch(ctx, obj, patch, opts...)
+					}
+
+					patchOptions := &client.PatchOptions{}
+					patchOptions.ApplyOptions(opts)
+					if len(patchOptions.DryRun) == 0 {
+						return nil
+					}
+
+					data, err := patch.Data(obj)
+					if err != nil {
+						return err
+					}
+					current, err := obj.(*unstructured.Unstructured).MarshalJSON()
+					if err != nil {
+						return err
+					}
+					patched, err := jsonpatch.MergePatch(current, data)
+					if err != nil {
+						return err
+					}
+
+					return obj.(*unstructured.Unstructured).UnmarshalJSON(patched)
+				},
+			}).
+			Build()
+	})
+
+	It("should report changes without persisting them", func(ctx context.Context) {
+		// given
+		Expect(deployComponent(ctx, manifests(map[string]string{
+			"tuned":   deployment("tuned", "quay.io/org/image:v1"),
+			"kept":    configMap("kept"),
+			"dropped": configMap("dropped"),
+		}))).To(Succeed())
+
+		tuned := &appsv1.Deployment{}
+		Expect(exists(ctx, tuned, "tuned")).To(BeTrue())
+		tuned.Spec.Replicas = ptr.To[int32](5)
+		Expect(cli.Update(ctx, tuned)).To(Succeed())
+
+		// when
+		planCtx, plan := deploy.WithPlan(ctx)
+		Expect(deployComponent(planCtx, manifests(map[string]string{
+			"tuned": deployment("tuned", "quay.io/org/image:v2"),
+			"kept":  configMap("kept"),
+			"added": configMap("added"),
+		}))).To(Succeed())
+
+		// then
+		changes := plan.Changes()
+		Expect(changes).To(HaveKey("component"))
+		Expect(changes["component"]).To(ConsistOf(
+			And(
+				HaveField("Action", featureresource.ActionPatch),
+				HaveField("Name", "tuned"),
+				HaveField("Diff", ContainSubstring("quay.io/org/image:v2")),
+				HaveField("Diff", Not(ContainSubstring("replicas"))),
+			),
+			And(HaveField("Action", featureresource.ActionNoOp), HaveField("Name", "kept")),
+			And(HaveField("Action", featureresource.ActionCreate), HaveField("Name", "added"), HaveField("Diff", BeEmpty())),
+			And(HaveField("Action", featureresource.ActionDelete), HaveField("Name", "dropped")),
+		))
+
+		Expect(exists(ctx, tuned, "tuned")).To(BeTrue())
+		Expect(tuned.Spec.Replicas).To(HaveValue(BeEquivalentTo(5)))
+		Expect(tuned.Spec.Template.Spec.Containers[0].Image).To(Equal("quay.io/org/image:v1"))
+		Expect(exists(ctx, &corev1.ConfigMap{}, "added")).To(BeFalse())
+		Expect(exists(ctx, &corev1.ConfigMap{}, "dropped")).To(BeTrue())
+
+		inventory := &corev1.ConfigMap{}
+		Expect(exists(ctx, inventory, "opendatahub-inventory-component")).To(BeTrue())
+		Expect(inventory.Data["resources"]).To(ContainSubstring("name: dropped"))
+		Expect(inventory.Data["resources"]).ToNot(ContainSubstring("name: added"))
+	})
+
+	It("should report deletion of resources of disabled components", func(ctx context.Context) {
+		// given
+		manifestPath := manifests(map[string]string{
+			"kept": configMap("kept"),
+		})
+		Expect(deployComponent(ctx, manifestPath)).To(Succeed())
+
+		// when
+		planCtx, plan := deploy.WithPlan(ctx)
+		Expect(deploy.DeployManifestsFromPath(planCtx, cli, dsc, manifestPath, appNamespace, "component", false)).To(Succeed())
+
+		// then
+		Expect(plan.Changes()["component"]).To(ConsistOf(
+			And(HaveField("Action", featureresource.ActionDelete), HaveField("Name", "kept")),
+		))
+		Expect(exists(ctx, &corev1.ConfigMap{}, "kept")).To(BeTrue())
+	})
+})
@@ -0,0 +1,160 @@
+package deploy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy/overlay"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+)
+
+// maxRenderCacheEntries bounds the cache, so entries of manifests changed behind the operator's back do not accumulate.
+const maxRenderCacheEntries = 256
+
+// renderCache keeps resources rendered by kustomize, keyed by the content hash of the manifests directory,
+// the overlaid files and the inputs of the plugins, so unchanged manifests are not built again on every reconcile.
+type renderCache struct {
+	mu      sync.Mutex
+	entries map[string]resmap.ResMap
+}
+
+var manifestsRenderCache = &renderCache{entries: map[string]resmap.ResMap{}}
+
+func (c *renderCache) get(key string) (resmap.ResMap, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resMap, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+
+	return resMap.DeepCopy(), true
+}
+
+func (c *renderCache) put(key string, resMap resmap.ResMap) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxRenderCacheEntries {
+		c.entries = map[string]resmap.ResMap{}
+	}
+	c.entries[key] = resMap.DeepCopy()
+}
+
+func (c *renderCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]resmap.ResMap{}
+}
+
+// InvalidateRenderCache drops all cached results of RenderManifests. Changes within the rendered directory
+// are detected by its content hash, but kustomizations can refer to files outside of it (e.g. `../base`),
+// so anything changing manifests on disk has to call it.
+func InvalidateRenderCache() {
+	manifestsRenderCache.invalidate()
+}
+
+// RenderManifests builds the kustomization in manifestPath (or its `default` overlay when there is none)
+// with the files edited in overlay.Manifests, and applies the namespace and component labels plugins to the result.
+// Rendered resources are cached, the returned ResMap is a copy which can be modified by the caller.
+func RenderManifests(manifestPath, namespace, componentName string) (resmap.ResMap, error) {
+	_, err := os.Stat(filepath.Join(manifestPath, "kustomization.yaml"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		manifestPath = filepath.Join(manifestPath, "default")
+	}
+
+	snapshot, err := overlay.Manifests.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey, err := renderCacheKey(manifestPath, snapshot.Digest(), namespace, componentName)
+	if err != nil {
+		return nil, err
+	}
+
+	if resMap, found := manifestsRenderCache.get(cacheKey); found {
+		return resMap, nil
+	}
+
+	fSys, err := snapshot.FileSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := k.Run(fSys, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	nsPlugin := plugins.CreateNamespaceApplierPlugin(namespace)
+	if err := nsPlugin.Transform(resMap); err != nil {
+		return nil, fmt.Errorf("failed applying namespace plugin when preparing Kustomize resources. %w", err)
+	}
+
+	labelsPlugin := plugins.CreateAddLabelsPlugin(componentName)
+	if err := labelsPlugin.Transform(resMap); err != nil {
+		return nil, fmt.Errorf("failed applying labels plugin when preparing Kustomize resources. %w", err)
+	}
+
+	manifestsRenderCache.put(cacheKey, resMap)
+
+	return resMap, nil
+}
+
+// renderCacheKey hashes paths and contents of all files in the manifests directory together with the digest
+// of the overlaid files and the plugin inputs.
+func renderCacheKey(manifestPath, overlayDigest, namespace, componentName string) (string, error) {
+	hasher := sha256.New()
+	for _, input := range []string{manifestPath, overlayDigest, namespace, componentName} {
+		hasher.Write([]byte(input))
+		hasher.Write([]byte{0})
+	}
+
+	if err := hashDirectory(hasher, manifestPath); err != nil {
+		return "", fmt.Errorf("failed computing content hash of %s: %w", manifestPath, err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// hashDirectory writes paths and contents of all files in the directory tree to the hasher.
+func hashDirectory(hasher hash.Hash, dir string) error {
+	return filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, errWalk error) error {
+		if errWalk != nil {
+			return errWalk
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		hasher.Write([]byte(filePath))
+		hasher.Write([]byte{0})
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(hasher, file)
+
+		return err
+	})
+}
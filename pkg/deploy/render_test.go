@@ -0,0 +1,177 @@
+package deploy_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rendering manifests", func() {
+
+	var (
+		componentDir string
+		overlayDir   string
+	)
+
+	writeManifest := func(path, content string) {
+		GinkgoHelper()
+		Expect(os.MkdirAll(filepath.Dir(path), os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	configMap := func(name, value string) string {
+		return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\ndata:\n  value: " + value + "\n"
+	}
+
+	renderedValue := func(namespace string) string {
+		GinkgoHelper()
+		resMap, err := deploy.RenderManifests(overlayDir, namespace, "component")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resMap.Resources()).To(HaveLen(1))
+
+		value, err := resMap.Resources()[0].GetString("data.value")
+		Expect(err).ToNot(HaveOccurred())
+
+		return value
+	}
+
+	BeforeEach(func() {
+		componentDir = GinkgoT().TempDir()
+		overlayDir = filepath.Join(componentDir, "overlays", "odh")
+		writeManifest(filepath.Join(componentDir, "base", "kustomization.yaml"), "resources:\n- config.yaml\n")
+		writeManifest(filepath.Join(componentDir, "base", "config.yaml"), configMap("config", "base"))
+		writeManifest(filepath.Join(overlayDir, "kustomization.yaml"), "resources:\n- ../../base\n")
+		DeferCleanup(deploy.InvalidateRenderCache)
+	})
+
+	It("should apply namespace and component label", func() {
+		// when
+		resMap, err := deploy.RenderManifests(overlayDir, "app-ns", "component")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resMap.Resources()[0].GetNamespace()).To(Equal("app-ns"))
+		Expect(resMap.Resources()[0].GetLabels()).To(HaveKeyWithValue("app.opendatahub.io/component", "true"))
+	})
+
+	It("should render default overlay when there is no kustomization", func() {
+		// given
+		writeManifest(filepath.Join(componentDir, "default", "kustomization.yaml"), "resources:\n- ../base\n")
+
+		// when
+		resMap, err := deploy.RenderManifests(componentDir, "app-ns", "component")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resMap.Resources()).To(HaveLen(1))
+	})
+
+	It("should return copies of cached resources", func() {
+		// given
+		resMap, err := deploy.RenderManifests(overlayDir, "app-ns", "component")
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		Expect(resMap.Resources()[0].SetName("modified")).To(Succeed())
+
+		// then
+		cachedResMap, err := deploy.RenderManifests(overlayDir, "app-ns", "component")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cachedResMap.Resources()[0].GetName()).To(Equal("config"))
+	})
+
+	It("should render again when the manifests directory changes", func() {
+		// given
+		Expect(renderedValue("app-ns")).To(Equal("base"))
+
+		// when
+		writeManifest(filepath.Join(overlayDir, "kustomization.yaml"), "resources:\n- ../../base\npatches:\n- path: patch.yaml\n")
+		writeManifest(filepath.Join(overlayDir, "patch.yaml"), configMap("config", "overlay"))
+
+		// then
+		Expect(renderedValue("app-ns")).To(Equal("overlay"))
+	})
+
+	It("should render separately for each namespace", func() {
+		// given
+		Expect(renderedValue("app-ns")).To(Equal("base"))
+		writeManifest(filepath.Join(componentDir, "base", "config.yaml"), configMap("config", "changed"))
+
+		// then
+		Expect(renderedValue("other-ns")).To(Equal("changed"))
+	})
+
+	It("should reuse rendered manifests until the cache is invalidated", func() {
+		// given
+		Expect(renderedValue("app-ns")).To(Equal("base"))
+
+		// when base outside of the rendered directory changes
+		writeManifest(filepath.Join(componentDir, "base", "config.yaml"), configMap("config", "changed"))
+
+		// then
+		Expect(renderedValue("app-ns")).To(Equal("base"))
+
+		deploy.InvalidateRenderCache()
+		Expect(renderedValue("app-ns")).To(Equal("changed"))
+	})
+
+	It("should render params applied in memory and changed back", func() {
+		// given
+		writeManifest(filepath.Join(componentDir, "base", "params.env"), "value=base\n")
+		writeManifest(filepath.Join(componentDir, "base", "kustomization.yaml"), `resources:
+- config.yaml
+configMapGenerator:
+- name: params
+  envs:
+  - params.env
+generatorOptions:
+  disableNameSuffixHash: true
+replacements:
+- source:
+    kind: ConfigMap
+    name: params
+    fieldPath: data.value
+  targets:
+  - select:
+      kind: ConfigMap
+      name: config
+    fieldPaths:
+    - data.value
+`)
+		resMap, err := deploy.RenderManifests(overlayDir, "app-ns", "component")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resMap.Resources()).To(HaveLen(2))
+
+		renderedValues := func() []string {
+			GinkgoHelper()
+			resMap, err := deploy.RenderManifests(overlayDir, "app-ns", "component")
+			Expect(err).ToNot(HaveOccurred())
+
+			var values []string
+			for _, res := range resMap.Resources() {
+				value, err := res.GetString("data.value")
+				Expect(err).ToNot(HaveOccurred())
+				values = append(values, value)
+			}
+
+			return values
+		}
+
+		// when
+		Expect(deploy.ApplyParams(filepath.Join(componentDir, "base"), nil, map[string]string{"value": "params"})).To(Succeed())
+
+		// then
+		Expect(renderedValues()).To(ConsistOf("params", "params"))
+		Expect(os.ReadFile(filepath.Join(componentDir, "base", "params.env"))).To(BeEquivalentTo("value=base\n"))
+
+		// when
+		Expect(deploy.ApplyParams(filepath.Join(componentDir, "base"), nil, map[string]string{"value": "base"})).To(Succeed())
+
+		// then
+		Expect(renderedValues()).To(ConsistOf("base", "base"))
+	})
+})
@@ -0,0 +1,463 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/oci"
+)
+
+// ManifestsCacheDir is the directory in which fetched manifests sources are cached, keyed by their digest.
+var ManifestsCacheDir = manifestsCacheDir()
+
+// MaxCachedManifestsSources limits the number of sources kept in ManifestsCacheDir, the least recently used ones are evicted.
+var MaxCachedManifestsSources = 16
+
+func manifestsCacheDir() string {
+	if dir := os.Getenv("MANIFESTS_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	return filepath.Join(os.TempDir(), "opendatahub-manifests-cache")
+}
+
+// ManifestsMountDir is the directory under which local manifests sources (file://) have to be located, e.g. volumes
+// mounted to the operator pod. Other directories of the operator file system cannot be used as manifests sources.
+var ManifestsMountDir = manifestsMountDir()
+
+func manifestsMountDir() string {
+	if dir := os.Getenv("MANIFESTS_MOUNT_DIR"); dir != "" {
+		return dir
+	}
+
+	return "/mnt/manifests"
+}
+
+var gitCommitPattern = regexp.MustCompile(`^[a-f0-9]{40}$`)
+
+// sourceCredentials are used to authenticate to the manifests source.
+type sourceCredentials struct {
+	username, password, token string
+}
+
+// DownloadManifests function performs following tasks:
+// 1. It fetches the manifests source defined by component URI, see fetchManifestsSource for supported sources.
+// 2. It installs only the folder specified by component.ContextDir field to the component-name/ folder of OverrideManifestPath,
+// on top of the manifests shipped for the component, see ManifestsPath. It is skipped when the same content
+// has already been installed.
+func DownloadManifests(ctx context.Context, cli client.Client, componentName string, manifestConfig components.ManifestsConfig) error {
+	if manifestConfig.AuthSecret != "" && strings.HasPrefix(strings.ToLower(manifestConfig.URI), "http://") {
+		return fmt.Errorf("credentials of %s cannot be sent over plain http, use https instead", manifestConfig.URI)
+	}
+
+	credentials, err := loadSourceCredentials(ctx, cli, manifestConfig.AuthSecret)
+	if err != nil {
+		return err
+	}
+
+	sourceDir, err := fetchManifestsSource(ctx, manifestConfig, credentials)
+	if err != nil {
+		return fmt.Errorf("error downloading manifests: %w", err)
+	}
+
+	contextDir := filepath.Join(sourceDir, filepath.Clean("/"+manifestConfig.ContextDir))
+
+	installed, err := installedManifests.install(contextDir, filepath.Join(DefaultManifestPath, componentName), filepath.Join(OverrideManifestPath, componentName))
+	if err != nil {
+		return err
+	}
+
+	if installed {
+		InvalidateRenderCache()
+	}
+
+	return nil
+}
+
+// fetchManifestsSource makes the source available on the local disk and returns its root directory. Supported sources are:
+//   - https:// tarballs, e.g. https://github.com/org/repo/tarball/<tag/branch>, with the top-level directory stripped,
+//   - oci:// artifacts, e.g. oci://quay.io/org/manifests:tag,
+//   - git+https:// repositories pinned to a commit, e.g. git+https://github.com/org/repo.git#<commit sha> (requires git binary),
+//   - file:// directories under ManifestsMountDir, e.g. file:///mnt/manifests/dashboard, used in place.
+//
+// Fetched sources are cached in ManifestsCacheDir, so they are reused when their digest is known upfront.
+func fetchManifestsSource(ctx context.Context, manifestConfig components.ManifestsConfig, credentials *sourceCredentials) (string, error) {
+	uri, err := url.Parse(manifestConfig.URI)
+	if err != nil {
+		return "", fmt.Errorf("invalid manifests URI %q: %w", manifestConfig.URI, err)
+	}
+
+	switch uri.Scheme {
+	case "https", "http":
+		return fetchTarball(ctx, manifestConfig.URI, manifestConfig.Digest, credentials)
+	case "oci":
+		return fetchOCIArtifact(ctx, strings.TrimPrefix(manifestConfig.URI, "oci://"), manifestConfig.Digest, credentials)
+	case "git+https":
+		if manifestConfig.Digest != "" {
+			return "", errors.New("digest verification is not supported for git sources, the pinned commit is verified instead")
+		}
+
+		return fetchGitCommit(ctx, "https://"+uri.Host+uri.Path, uri.Fragment, credentials)
+	case "file":
+		if manifestConfig.Digest != "" {
+			return "", errors.New("digest verification is not supported for local directories")
+		}
+
+		return localDirectory(uri.Path)
+	default:
+		return "", fmt.Errorf("unsupported manifests URI scheme %q", uri.Scheme)
+	}
+}
+
+// localDirectory resolves the directory, including symbolic links, and ensures it is located under ManifestsMountDir.
+func localDirectory(path string) (string, error) {
+	dir, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("manifests directory %s is not available: %w", path, err)
+	}
+
+	root, err := filepath.EvalSymlinks(ManifestsMountDir)
+	if err != nil {
+		return "", fmt.Errorf("manifests mount directory %s is not available: %w", ManifestsMountDir, err)
+	}
+
+	if relative, errRel := filepath.Rel(root, dir); errRel != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("manifests directory %s is not located under %s", path, ManifestsMountDir)
+	}
+
+	if info, errStat := os.Stat(dir); errStat != nil {
+		return "", fmt.Errorf("manifests directory %s is not available: %w", path, errStat)
+	} else if !info.IsDir() {
+		return "", fmt.Errorf("manifests source %s is not a directory", path)
+	}
+
+	return dir, nil
+}
+
+func fetchTarball(ctx context.Context, tarballURL, expectedDigest string, credentials *sourceCredentials) (string, error) {
+	if cachedDir, cached := cachedSource(expectedDigest); cached {
+		return cachedDir, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tarballURL, nil)
+	if err != nil {
+		return "", err
+	}
+	credentials.authorize(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%v HTTP status", resp.StatusCode)
+	}
+
+	// tarball is kept on disk until its digest is verified, so unverified content is never extracted
+	if err = os.MkdirAll(ManifestsCacheDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("error creating manifests cache directory: %w", err)
+	}
+	tarball, err := os.CreateTemp(ManifestsCacheDir, ".download-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tarball.Name())
+	defer tarball.Close()
+
+	// compressed tarball is bounded by the total size of its content, so oversized downloads are not stored on disk
+	digester := sha256.New()
+	downloaded, err := io.Copy(io.MultiWriter(tarball, digester), io.LimitReader(resp.Body, TarballLimits.MaxTotalSize+1))
+	if err != nil {
+		return "", fmt.Errorf("error downloading file contents: %w", err)
+	}
+	if downloaded > TarballLimits.MaxTotalSize {
+		return "", fmt.Errorf("download of %s exceeds the limit of %d bytes in total", tarballURL, TarballLimits.MaxTotalSize)
+	}
+
+	digest, err := verifyDigest(digester, expectedDigest)
+	if err != nil {
+		return "", err
+	}
+
+	return storeInCache(digest, func(dir string) error {
+		if _, errSeek := tarball.Seek(0, io.SeekStart); errSeek != nil {
+			return errSeek
+		}
+
+		// tarballs of git repositories have the content nested in the top-level directory
+		return extractTarball(tarball, dir, 1)
+	})
+}
+
+func fetchOCIArtifact(ctx context.Context, image, expectedDigest string, credentials *sourceCredentials) (string, error) {
+	if cachedDir, cached := cachedSource(expectedDigest); cached {
+		return cachedDir, nil
+	}
+
+	if expectedDigest != "" {
+		ref, errRef := oci.ParseReference(image)
+		if errRef != nil {
+			return "", errRef
+		}
+		ref.Reference = expectedDigest
+		image = ref.String()
+	}
+
+	ociClient := &oci.Client{}
+	if credentials != nil {
+		ociClient.Username = credentials.username
+		ociClient.Password = credentials.password
+		if credentials.token != "" {
+			ociClient.Password = credentials.token
+		}
+	}
+
+	artifact, err := ociClient.Pull(ctx, image)
+	if err != nil {
+		return "", err
+	}
+
+	if expectedDigest != "" && artifact.Digest != expectedDigest {
+		return "", fmt.Errorf("digest %s of %s does not match expected %s", artifact.Digest, image, expectedDigest)
+	}
+
+	return storeInCache(artifact.Digest, func(dir string) error {
+		for name, content := range artifact.Files {
+			target := filepath.Join(dir, filepath.FromSlash(name))
+			if errDir := os.MkdirAll(filepath.Dir(target), os.ModePerm); errDir != nil {
+				return errDir
+			}
+			if errWrite := os.WriteFile(target, content, 0o600); errWrite != nil {
+				return errWrite
+			}
+		}
+
+		return nil
+	})
+}
+
+func fetchGitCommit(ctx context.Context, repositoryURL, commit string, credentials *sourceCredentials) (string, error) {
+	if !gitCommitPattern.MatchString(commit) {
+		return "", fmt.Errorf("git source %s has to be pinned to a full commit sha, e.g. git+https://github.com/org/repo.git#<commit sha>", repositoryURL)
+	}
+
+	// the same commit can be fetched from different repositories, e.g. forks, which could serve different content
+	cacheKey := "git:" + repositoryKey(repositoryURL) + ":" + commit
+	if cachedDir, cached := cachedSource(cacheKey); cached {
+		return cachedDir, nil
+	}
+
+	return storeInCache(cacheKey, func(dir string) error {
+		// credentials are passed in the environment rather than arguments, which are visible to anyone listing processes
+		var authEnv []string
+		if header := credentials.authorizationHeader(); header != "" {
+			authEnv = []string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=http.extraHeader", "GIT_CONFIG_VALUE_0=Authorization: " + header}
+		}
+
+		for _, args := range [][]string{
+			{"init", "--quiet"},
+			{"fetch", "--quiet", "--depth=1", repositoryURL, commit},
+			{"checkout", "--quiet", "FETCH_HEAD"},
+		} {
+			if _, errGit := runGit(ctx, dir, authEnv, args...); errGit != nil {
+				return errGit
+			}
+		}
+
+		head, errHead := runGit(ctx, dir, nil, "rev-parse", "HEAD")
+		if errHead != nil {
+			return errHead
+		}
+		if head != commit {
+			return fmt.Errorf("fetched commit %s of %s does not match pinned %s", head, repositoryURL, commit)
+		}
+
+		return os.RemoveAll(filepath.Join(dir, ".git"))
+	})
+}
+
+// repositoryKey identifies the repository by the digest of its normalized URL, so different spellings of the same
+// repository URL share cached commits.
+func repositoryKey(repositoryURL string) string {
+	normalized := repositoryURL
+	if uri, err := url.Parse(repositoryURL); err == nil {
+		normalized = strings.ToLower(uri.Host) + strings.TrimSuffix(strings.TrimSuffix(uri.Path, "/"), ".git")
+	}
+
+	digest := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(digest[:8])
+}
+
+func runGit(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), env...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func verifyDigest(digester hash.Hash, expectedDigest string) (string, error) {
+	digest := "sha256:" + hex.EncodeToString(digester.Sum(nil))
+	if expectedDigest != "" && digest != expectedDigest {
+		return "", fmt.Errorf("digest %s does not match expected %s", digest, expectedDigest)
+	}
+
+	return digest, nil
+}
+
+func cachedSource(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+
+	dir := filepath.Join(ManifestsCacheDir, cacheEntryName(key))
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return dir, false
+	}
+
+	// modification time tracks the last use of the entry, see evictCachedSources
+	now := time.Now()
+	_ = os.Chtimes(dir, now, now)
+
+	return dir, true
+}
+
+// storeInCache populates the cache entry using a temporary directory, so incomplete entries are never visible.
+func storeInCache(key string, populate func(dir string) error) (string, error) {
+	if err := os.MkdirAll(ManifestsCacheDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("error creating manifests cache directory: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp(ManifestsCacheDir, ".entry-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = populate(tmpDir); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(ManifestsCacheDir, cacheEntryName(key))
+	if cachedDir, cached := cachedSource(key); cached {
+		return cachedDir, nil
+	}
+
+	if err = os.Rename(tmpDir, dir); err != nil {
+		return "", err
+	}
+
+	evictCachedSources(dir)
+
+	return dir, nil
+}
+
+// evictCachedSources removes the least recently used entries exceeding MaxCachedManifestsSources, except the one in use.
+// Eviction is best effort, entries which cannot be removed are left for the next attempt.
+func evictCachedSources(inUse string) {
+	entries, err := os.ReadDir(ManifestsCacheDir)
+	if err != nil {
+		return
+	}
+
+	type cachedEntry struct {
+		dir      string
+		lastUsed time.Time
+	}
+	var cached []cachedEntry
+	for _, entry := range entries {
+		// entries being populated and downloads in progress are hidden
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, errInfo := entry.Info()
+		if errInfo != nil {
+			continue
+		}
+		cached = append(cached, cachedEntry{dir: filepath.Join(ManifestsCacheDir, entry.Name()), lastUsed: info.ModTime()})
+	}
+
+	sort.Slice(cached, func(i, j int) bool {
+		return cached[i].lastUsed.After(cached[j].lastUsed)
+	})
+
+	for i := MaxCachedManifestsSources; i < len(cached); i++ {
+		if cached[i].dir != inUse {
+			_ = os.RemoveAll(cached[i].dir)
+		}
+	}
+}
+
+func cacheEntryName(key string) string {
+	return strings.ReplaceAll(key, ":", "-")
+}
+
+func loadSourceCredentials(ctx context.Context, cli client.Client, secretName string) (*sourceCredentials, error) {
+	if secretName == "" {
+		return nil, nil //nolint:nilnil // Reason: anonymous access
+	}
+
+	operatorNamespace, err := cluster.GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err = cli.Get(ctx, client.ObjectKey{Namespace: operatorNamespace, Name: secretName}, secret); err != nil {
+		return nil, fmt.Errorf("failed getting credentials of manifests source from Secret %s/%s: %w", operatorNamespace, secretName, err)
+	}
+
+	return &sourceCredentials{
+		username: string(secret.Data["username"]),
+		password: string(secret.Data["password"]),
+		token:    string(secret.Data["token"]),
+	}, nil
+}
+
+func (c *sourceCredentials) authorizationHeader() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.token != "":
+		return "Bearer " + c.token
+	case c.username != "" || c.password != "":
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password))
+	default:
+		return ""
+	}
+}
+
+func (c *sourceCredentials) authorize(req *http.Request) {
+	if header := c.authorizationHeader(); header != "" {
+		req.Header.Set("Authorization", header)
+	}
+}
@@ -0,0 +1,285 @@
+package deploy_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Downloading manifests", func() {
+
+	var (
+		manifestsDir string
+		downloads    int
+		tarball      []byte
+		server       *httptest.Server
+	)
+
+	BeforeEach(func() {
+		manifestsDir = GinkgoT().TempDir()
+		originalManifestPath, originalOverridePath, originalCacheDir := deploy.DefaultManifestPath, deploy.OverrideManifestPath, deploy.ManifestsCacheDir
+		originalMountDir := deploy.ManifestsMountDir
+		deploy.DefaultManifestPath, deploy.OverrideManifestPath, deploy.ManifestsCacheDir = GinkgoT().TempDir(), manifestsDir, GinkgoT().TempDir()
+		deploy.ManifestsMountDir = GinkgoT().TempDir()
+		DeferCleanup(func() {
+			deploy.DefaultManifestPath, deploy.OverrideManifestPath, deploy.ManifestsCacheDir = originalManifestPath, originalOverridePath, originalCacheDir
+			deploy.ManifestsMountDir = originalMountDir
+		})
+
+		tarball = createTarball(
+			regularFile("org-repo-1234/README.md", "# repo"),
+			regularFile("org-repo-1234/manifests/base/kustomization.yaml", "resources: []"),
+		)
+		downloads = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			downloads++
+			_, _ = w.Write(tarball)
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	mountedDir := func() string {
+		GinkgoHelper()
+		dir, err := os.MkdirTemp(deploy.ManifestsMountDir, "source-")
+		Expect(err).ToNot(HaveOccurred())
+
+		return dir
+	}
+
+	digestOf := func(content []byte) string {
+		return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+	}
+
+	It("should copy context directory of the tarball", func(ctx context.Context) {
+		// when
+		err := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        server.URL + "/tarball/main",
+			ContextDir: "manifests",
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filepath.Join(manifestsDir, "component", "base", "kustomization.yaml")).To(BeARegularFile())
+		Expect(filepath.Join(manifestsDir, "component", "README.md")).ToNot(BeAnExistingFile())
+	})
+
+	It("should reuse cached tarball when digest matches", func(ctx context.Context) {
+		// given
+		manifestConfig := components.ManifestsConfig{
+			URI:        server.URL + "/tarball/main",
+			Digest:     digestOf(tarball),
+			ContextDir: "manifests",
+		}
+
+		// when
+		Expect(deploy.DownloadManifests(ctx, nil, "component", manifestConfig)).To(Succeed())
+		Expect(deploy.DownloadManifests(ctx, nil, "other-component", manifestConfig)).To(Succeed())
+
+		// then
+		Expect(downloads).To(Equal(1))
+		Expect(filepath.Join(manifestsDir, "other-component", "base", "kustomization.yaml")).To(BeARegularFile())
+	})
+
+	It("should reject tarball not matching the digest", func(ctx context.Context) {
+		// when
+		err := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        server.URL + "/tarball/main",
+			Digest:     digestOf([]byte("something else")),
+			ContextDir: "manifests",
+		})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("does not match expected")))
+		Expect(filepath.Join(manifestsDir, "component")).ToNot(BeAnExistingFile())
+	})
+
+	It("should stop downloading tarball exceeding the size limit", func(ctx context.Context) {
+		// given
+		originalLimits := deploy.TarballLimits
+		deploy.TarballLimits.MaxTotalSize = int64(len(tarball) - 1)
+		DeferCleanup(func() {
+			deploy.TarballLimits = originalLimits
+		})
+
+		// when
+		err := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        server.URL + "/tarball/main",
+			ContextDir: "manifests",
+		})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("exceeds the limit of %d bytes in total", len(tarball)-1))))
+		Expect(filepath.Join(manifestsDir, "component")).ToNot(BeAnExistingFile())
+	})
+
+	It("should copy manifests from local directory", func(ctx context.Context) {
+		// given
+		sourceDir := mountedDir()
+		Expect(os.MkdirAll(filepath.Join(sourceDir, "manifests", "overlays"), os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(sourceDir, "manifests", "overlays", "kustomization.yaml"), []byte("resources: []"), 0o600)).To(Succeed())
+
+		// when
+		err := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        "file://" + sourceDir,
+			ContextDir: "manifests",
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filepath.Join(manifestsDir, "component", "overlays", "kustomization.yaml")).To(BeARegularFile())
+	})
+
+	It("should not copy manifests from local directory outside of the mount directory", func(ctx context.Context) {
+		// given
+		sourceDir := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(sourceDir, "manifests"), os.ModePerm)).To(Succeed())
+		Expect(os.Symlink(sourceDir, filepath.Join(deploy.ManifestsMountDir, "link"))).To(Succeed())
+
+		// when
+		errOutside := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        "file://" + sourceDir,
+			ContextDir: "manifests",
+		})
+		errLinked := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        "file://" + filepath.Join(deploy.ManifestsMountDir, "link"),
+			ContextDir: "manifests",
+		})
+
+		// then
+		Expect(errOutside).To(MatchError(ContainSubstring("is not located under " + deploy.ManifestsMountDir)))
+		Expect(errLinked).To(MatchError(ContainSubstring("is not located under " + deploy.ManifestsMountDir)))
+		Expect(filepath.Join(manifestsDir, "component")).ToNot(BeAnExistingFile())
+	})
+
+	It("should not install unchanged manifests again", func(ctx context.Context) {
+		// given
+		sourceDir := mountedDir()
+		Expect(os.MkdirAll(filepath.Join(sourceDir, "manifests"), os.ModePerm)).To(Succeed())
+		kustomization := filepath.Join(sourceDir, "manifests", "kustomization.yaml")
+		Expect(os.WriteFile(kustomization, []byte("resources: []"), 0o600)).To(Succeed())
+		manifestConfig := components.ManifestsConfig{URI: "file://" + sourceDir, ContextDir: "manifests"}
+		Expect(deploy.DownloadManifests(ctx, nil, "component", manifestConfig)).To(Succeed())
+		marker := filepath.Join(manifestsDir, "component", "installed")
+		Expect(os.WriteFile(marker, []byte("first"), 0o600)).To(Succeed())
+
+		// when
+		Expect(deploy.DownloadManifests(ctx, nil, "component", manifestConfig)).To(Succeed())
+
+		// then
+		Expect(marker).To(BeARegularFile())
+
+		// when
+		Expect(os.WriteFile(kustomization, []byte("resources: [cm.yaml]"), 0o600)).To(Succeed())
+		Expect(deploy.DownloadManifests(ctx, nil, "component", manifestConfig)).To(Succeed())
+
+		// then
+		Expect(marker).ToNot(BeAnExistingFile())
+		Expect(os.ReadFile(filepath.Join(manifestsDir, "component", "kustomization.yaml"))).To(BeEquivalentTo("resources: [cm.yaml]"))
+	})
+
+	It("should serialize installs to the same directory", func(ctx context.Context) {
+		// given
+		var sources []components.ManifestsConfig
+		for i := 0; i < 2; i++ {
+			sourceDir := mountedDir()
+			Expect(os.MkdirAll(filepath.Join(sourceDir, "manifests"), os.ModePerm)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(sourceDir, "manifests", "kustomization.yaml"), []byte(fmt.Sprintf("resources: [cm-%d.yaml]", i)), 0o600)).To(Succeed())
+			sources = append(sources, components.ManifestsConfig{URI: "file://" + sourceDir, ContextDir: "manifests"})
+		}
+
+		// when
+		errs := make(chan error, 20)
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(manifestConfig components.ManifestsConfig) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				errs <- deploy.DownloadManifests(ctx, nil, "odh-model-controller", manifestConfig)
+			}(sources[i%2])
+		}
+		wg.Wait()
+		close(errs)
+
+		// then
+		for err := range errs {
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(filepath.Join(manifestsDir, "odh-model-controller", "kustomization.yaml")).To(BeARegularFile())
+	})
+
+	It("should require git source to be pinned to a commit", func(ctx context.Context) {
+		// when
+		err := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI: "git+https://github.com/org/repo.git#main",
+		})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("has to be pinned to a full commit sha")))
+	})
+
+	It("should evict least recently used sources from the cache", func(ctx context.Context) {
+		// given
+		originalMaxCached := deploy.MaxCachedManifestsSources
+		deploy.MaxCachedManifestsSources = 1
+		DeferCleanup(func() {
+			deploy.MaxCachedManifestsSources = originalMaxCached
+		})
+
+		firstTarball := tarball
+		secondTarball := createTarball(regularFile("org-repo-5678/manifests/base/kustomization.yaml", "resources: []"))
+		download := func(content []byte) {
+			GinkgoHelper()
+			tarball = content
+			Expect(deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+				URI:        server.URL + "/tarball/main",
+				Digest:     digestOf(content),
+				ContextDir: "manifests",
+			})).To(Succeed())
+		}
+
+		// when
+		download(firstTarball)
+		download(secondTarball)
+		download(firstTarball)
+
+		// then
+		Expect(downloads).To(Equal(3))
+		cached, err := os.ReadDir(deploy.ManifestsCacheDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cached).To(HaveLen(1))
+	})
+
+	It("should not send credentials over plain http", func(ctx context.Context) {
+		// when
+		err := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI:        server.URL + "/tarball/main",
+			AuthSecret: "manifests-credentials",
+		})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("cannot be sent over plain http")))
+		Expect(downloads).To(BeZero())
+	})
+
+	It("should reject unsupported scheme", func(ctx context.Context) {
+		// when
+		err := deploy.DownloadManifests(ctx, nil, "component", components.ManifestsConfig{
+			URI: "ftp://example.com/manifests.tar.gz",
+		})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("unsupported manifests URI scheme")))
+	})
+})
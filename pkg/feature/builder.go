@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/blang/semver/v4"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -20,6 +21,9 @@ type featureBuilder struct {
 	source      featurev1.Source
 	owner       metav1.Object
 	targetNs    string
+	dependsOn   []string
+	retryPolicy RetryPolicy
+	nonBlocking bool
 
 	builders []partialBuilder
 }
@@ -28,6 +32,7 @@ type featureBuilder struct {
 func Define(featureName string) *featureBuilder {
 	fb := &featureBuilder{
 		featureName: featureName,
+		retryPolicy: DefaultRetryPolicy,
 		source: featurev1.Source{
 			Type: featurev1.UnknownType,
 			Name: featureName,
@@ -87,6 +92,32 @@ func (fb *featureBuilder) Manifests(creators ...resource.Creator) *featureBuilde
 	return fb
 }
 
+// DependsOn declares features which have to be successfully applied before this one.
+// Features handled by the same FeaturesHandler which do not depend on each other are applied concurrently.
+// If any of the dependencies fails, applying this feature is skipped and reported in its FeatureTracker.
+func (fb *featureBuilder) DependsOn(featureNames ...string) *featureBuilder {
+	fb.dependsOn = append(fb.dependsOn, featureNames...)
+
+	return fb
+}
+
+// RetryPolicy defines how long and how often conditions of the feature, such as WaitForPodsToBeReady, are checked.
+// The policy can be overridden for a particular condition using WithRetryPolicy. DefaultRetryPolicy is used if not set.
+func (fb *featureBuilder) RetryPolicy(policy RetryPolicy) *featureBuilder {
+	fb.retryPolicy = policy
+
+	return fb
+}
+
+// NonBlocking makes the conditions of the feature check the cluster state only once instead of waiting for it.
+// If the condition is not met, the feature is reported as progressing in its FeatureTracker and Apply returns
+// NotReadyError, so the caller can requeue the reconciliation (see IsNotReady) rather than blocking the worker.
+func (fb *featureBuilder) NonBlocking() *featureBuilder {
+	fb.nonBlocking = true
+
+	return fb
+}
+
 // OwnedBy is optionally used to pass down the owning object in order to set the ownerReference
 // in the corresponding feature tracker.
 func (fb *featureBuilder) OwnedBy(object metav1.Object) *featureBuilder {
@@ -163,6 +194,20 @@ func (fb *featureBuilder) PreConditions(preconditions ...Action) *featureBuilder
 	return fb
 }
 
+// Warnings adds checks which, unlike PreConditions, do not prevent the feature from being applied when they fail.
+// Their failures are reported as Degraded condition of the FeatureTracker and as WarningError to the reporter
+// of HandlerWithReporter, so they can be surfaced in the condition of the capability the feature belongs to.
+// Warnings are checked also when the feature is disabled, so they can explain why it is not applied.
+func (fb *featureBuilder) Warnings(warnings ...Action) *featureBuilder {
+	fb.builders = append(fb.builders, func(f *Feature) error {
+		f.warnings = append(f.warnings, warnings...)
+
+		return nil
+	})
+
+	return fb
+}
+
 // PostConditions adds postconditions to the feature. Postconditions are actions that are executed after the feature is applied.
 func (fb *featureBuilder) PostConditions(postconditions ...Action) *featureBuilder {
 	fb.builders = append(fb.builders, func(f *Feature) error {
@@ -174,6 +219,41 @@ func (fb *featureBuilder) PostConditions(postconditions ...Action) *featureBuild
 	return fb
 }
 
+// Migrations adds actions which migrate resources created by operator releases older than `from`, e.g. by patching them
+// using ApplyManifests. Migrations are run after manifests of the feature are applied, only once per FeatureTracker:
+// when the release recorded in the FeatureTracker precedes `from` and the running operator release is at least `from`.
+// Newly created FeatureTrackers are considered migrated, as there is nothing to migrate on fresh installations.
+func (fb *featureBuilder) Migrations(from semver.Version, actions ...Action) *featureBuilder {
+	fb.builders = append(fb.builders, func(f *Feature) error {
+		f.migrations = append(f.migrations, migration{from: from, actions: actions})
+
+		return nil
+	})
+
+	return fb
+}
+
+// OnDeleteManifests allows to define manifests which are applied when the feature is going to be deleted.
+// This is useful for inverse patches, which revert changes the feature made to resources it does not own.
+// Feature data is loaded beforehand, so templates can use the same data as when the feature is applied.
+func (fb *featureBuilder) OnDeleteManifests(creators ...resource.Creator) *featureBuilder {
+	for i := range creators {
+		creator := creators[i]
+		fb.builders = append(fb.builders, func(f *Feature) error {
+			appliers, errCreate := creator.Create()
+			if errCreate != nil {
+				return errCreate
+			}
+
+			f.addCleanup(applyOnCleanup(f, appliers))
+
+			return nil
+		})
+	}
+
+	return fb
+}
+
 // OnDelete allow to add cleanup hooks that are executed when the feature is going to be deleted.
 func (fb *featureBuilder) OnDelete(cleanups ...CleanupFunc) *featureBuilder {
 	fb.builders = append(fb.builders, func(f *Feature) error {
@@ -192,13 +272,22 @@ func (fb *featureBuilder) Create() (*Feature, error) {
 		return true, nil
 	}
 
+	for _, dependency := range fb.dependsOn {
+		if dependency == fb.featureName {
+			return nil, fmt.Errorf("feature '%s' cannot depend on itself", fb.featureName)
+		}
+	}
+
 	f := &Feature{
-		Name:    fb.featureName,
-		Managed: fb.managed,
-		Enabled: alwaysEnabled,
-		Log:     log.Log.WithName("features").WithValues("feature", fb.featureName),
-		source:  &fb.source,
-		owner:   fb.owner,
+		Name:        fb.featureName,
+		Managed:     fb.managed,
+		Enabled:     alwaysEnabled,
+		Log:         log.Log.WithName("features").WithValues("feature", fb.featureName),
+		source:      &fb.source,
+		owner:       fb.owner,
+		dependsOn:   fb.dependsOn,
+		retryPolicy: fb.retryPolicy,
+		nonBlocking: fb.nonBlocking,
 	}
 
 	for i := range fb.builders {
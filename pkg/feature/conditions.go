@@ -3,22 +3,15 @@ package feature
 import (
 	"context"
 	"fmt"
-	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 )
 
-const (
-	interval = 2 * time.Second
-	duration = 5 * time.Minute
-)
-
 type MissingOperatorError struct {
 	operatorName string
 	err          error
@@ -31,6 +24,11 @@ func NewMissingOperatorError(operatorName string, err error) *MissingOperatorErr
 	}
 }
 
+// OperatorName returns the name of the operator which is not installed.
+func (e *MissingOperatorError) OperatorName() string {
+	return e.operatorName
+}
+
 func (e *MissingOperatorError) Unwrap() error {
 	return e.err
 }
@@ -54,14 +52,12 @@ func EnsureOperatorIsInstalled(operatorName string) Action {
 
 func WaitForPodsToBeReady(namespace string) Action {
 	return func(ctx context.Context, cli client.Client, f *Feature) error {
-		f.Log.Info("waiting for pods to become ready", "namespace", namespace, "duration (s)", duration.Seconds())
-
-		return wait.PollUntilContextTimeout(ctx, interval, duration, false, func(ctx context.Context) (bool, error) {
+		return WaitUntil("pods to become ready in namespace "+namespace, func(ctx context.Context, cli client.Client) (bool, string, error) {
 			var podList corev1.PodList
 
 			err := cli.List(ctx, &podList, client.InNamespace(namespace))
 			if err != nil {
-				return false, err
+				return false, "", err
 			}
 
 			podList.Items = filterEvictedPods(podList.Items)
@@ -69,7 +65,7 @@ func WaitForPodsToBeReady(namespace string) Action {
 			totalPods := len(podList.Items)
 
 			if totalPods == 0 { // We want to wait for "something", so make sure we have "something" before we claim success.
-				return false, nil
+				return false, "no pods found", nil
 			}
 
 			for _, pod := range podList.Items {
@@ -98,8 +94,8 @@ func WaitForPodsToBeReady(namespace string) Action {
 				f.Log.Info("done waiting for pods to become ready", "namespace", namespace)
 			}
 
-			return done, nil
-		})
+			return done, fmt.Sprintf("%d/%d pods ready", readyPods, totalPods), nil
+		})(ctx, cli, f)
 	}
 }
 
@@ -117,9 +113,7 @@ func filterEvictedPods(pods []corev1.Pod) []corev1.Pod {
 
 func WaitForResourceToBeCreated(namespace string, gvk schema.GroupVersionKind) Action {
 	return func(ctx context.Context, cli client.Client, f *Feature) error {
-		f.Log.Info("waiting for resource to be created", "namespace", namespace, "resource", gvk)
-
-		return wait.PollUntilContextTimeout(ctx, interval, duration, false, func(ctx context.Context) (bool, error) {
+		return f.WaitFor(ctx, fmt.Sprintf("%s to be created in namespace %s", gvk.Kind, namespace), func(ctx context.Context) (bool, error) {
 			list := &unstructured.UnstructuredList{}
 			list.SetGroupVersionKind(gvk)
 
@@ -0,0 +1,129 @@
+package feature_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dependencies between features", func() {
+
+	var dsci *dsciv1.DSCInitialization
+
+	BeforeEach(func() {
+		dsci = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+		}
+	})
+
+	It("should reject feature depending on itself", func() {
+		_, err := feature.Define("self-dependent").
+			TargetNamespace("test-ns").
+			DependsOn("self-dependent").
+			Create()
+
+		Expect(err).To(MatchError(ContainSubstring("cannot depend on itself")))
+	})
+
+	It("should reject features forming a dependency cycle", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define("feature-a").DependsOn("feature-c"),
+				feature.Define("feature-b").DependsOn("feature-a"),
+				feature.Define("feature-c").DependsOn("feature-b"),
+			)
+		})
+
+		// when
+		err := featuresHandler.Apply(ctx, nil)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("dependency cycle detected between features: feature-a -> feature-c -> feature-b -> feature-a")))
+	})
+
+	It("should reject feature depending on undefined feature", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define("feature-a").DependsOn("not-defined"),
+			)
+		})
+
+		// when
+		err := featuresHandler.Delete(ctx, nil)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("feature 'feature-a' depends on undefined feature 'not-defined'")))
+	})
+
+	It("should apply features with limited number of workers after their dependencies", func(ctx context.Context) {
+		// given
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(featurev1.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dsci).
+			WithStatusSubresource(&featurev1.FeatureTracker{}).
+			Build()
+
+		var (
+			mu            sync.Mutex
+			running, peak int
+			applied       []string
+		)
+		tracked := func(_ context.Context, _ client.Client, f *feature.Feature) error {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			applied = append(applied, f.Name)
+			mu.Unlock()
+
+			return nil
+		}
+
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			for i := 0; i < 2*feature.MaxConcurrentFeatures; i++ {
+				if err := registry.Add(feature.Define(fmt.Sprintf("feature-%d", i)).PreConditions(tracked)); err != nil {
+					return err
+				}
+			}
+
+			return registry.Add(feature.Define("dependent").DependsOn("feature-0", "feature-1").PreConditions(tracked))
+		})
+
+		// when
+		err := featuresHandler.Apply(ctx, cli)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applied).To(HaveLen(2*feature.MaxConcurrentFeatures + 1))
+		Expect(peak).To(BeNumerically("<=", feature.MaxConcurrentFeatures))
+		Expect(slices.Index(applied, "dependent")).To(BeNumerically(">", slices.Index(applied, "feature-0")))
+		Expect(slices.Index(applied, "dependent")).To(BeNumerically(">", slices.Index(applied, "feature-1")))
+	})
+})
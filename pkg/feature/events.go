@@ -0,0 +1,89 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+)
+
+// Reasons of the Events emitted for the transitions of the feature lifecycle.
+const (
+	EventReasonFeatureStarted       = "FeatureStarted"
+	EventReasonPreConditionsFailed  = "PreConditionsFailed"
+	EventReasonMissingOperator      = "MissingOperator"
+	EventReasonManifestsApplied     = "ManifestsApplied"
+	EventReasonPostConditionsFailed = "PostConditionsFailed"
+	EventReasonPostConditionTimeout = "PostConditionTimeout"
+	EventReasonCleanupDone          = "CleanupDone"
+)
+
+//nolint:gochecknoglobals // Reason: recorder is shared by all the features created by the operator
+var (
+	eventRecorder      record.EventRecorder = &record.FakeRecorder{}
+	eventRecorderMutex sync.RWMutex
+)
+
+// SetEventRecorder configures the recorder used to emit Events about the feature lifecycle.
+// Events are emitted on the FeatureTracker and on the object owning the feature (e.g. DSCInitialization or DataScienceCluster).
+// Until the recorder is set, events are discarded.
+func SetEventRecorder(recorder record.EventRecorder) {
+	eventRecorderMutex.Lock()
+	defer eventRecorderMutex.Unlock()
+
+	eventRecorder = recorder
+}
+
+func (f *Feature) emitEvent(eventType, reason, messageFmt string, args ...any) {
+	eventRecorderMutex.RLock()
+	recorder := eventRecorder
+	eventRecorderMutex.RUnlock()
+
+	message := fmt.Sprintf(messageFmt, args...)
+
+	if f.tracker != nil {
+		recorder.Event(f.tracker, eventType, reason, message)
+	}
+
+	if owner, isRuntimeObj := f.owner.(runtime.Object); isRuntimeObj && owner != nil {
+		recorder.Event(owner, eventType, reason, message)
+	}
+}
+
+// emitApplyResultEvent emits a warning for failures of the feature which are worth the attention of the cluster admin.
+func (f *Feature) emitApplyResultEvent(applyErr error) {
+	if applyErr == nil {
+		return
+	}
+
+	if _, notReady := IsNotReady(applyErr); notReady {
+		return
+	}
+
+	switch reasonOf(applyErr) { //nolint:exhaustive // Reason: other failures are reported in FeatureTracker status only
+	case featurev1.ConditionReason.PreConditions:
+		var missingOperatorErr *MissingOperatorError
+		if errors.As(applyErr, &missingOperatorErr) {
+			f.emitEvent(corev1.EventTypeWarning, EventReasonMissingOperator,
+				"Feature [%s] requires operator %q which is not installed", f.Name, missingOperatorErr.OperatorName())
+
+			return
+		}
+
+		f.emitEvent(corev1.EventTypeWarning, EventReasonPreConditionsFailed, "Preconditions of feature [%s] failed: %v", f.Name, applyErr)
+	case featurev1.ConditionReason.PostConditions:
+		if wait.Interrupted(applyErr) {
+			f.emitEvent(corev1.EventTypeWarning, EventReasonPostConditionTimeout, "Timed out waiting for postconditions of feature [%s]: %v", f.Name, applyErr)
+
+			return
+		}
+
+		f.emitEvent(corev1.EventTypeWarning, EventReasonPostConditionsFailed, "Postconditions of feature [%s] failed: %v", f.Name, applyErr)
+	}
+}
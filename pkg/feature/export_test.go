@@ -0,0 +1,15 @@
+package feature
+
+var CurrentRelease = currentRelease
+
+// SetDevelopmentVersion overrides the version set at build time, returning a function restoring it.
+func SetDevelopmentVersion(version string) func() {
+	previous := developmentVersion
+	developmentVersion = version
+
+	return func() {
+		developmentVersion = previous
+	}
+}
+
+const MaxConcurrentFeatures = maxConcurrentFeatures
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -30,8 +31,12 @@ import (
 // resource establishes ownership for related resources, allowing for easy cleanup of all resources
 // associated with the feature when it is about to be removed during reconciliation.
 //
+// Migrations of the feature bring resources created by older releases of the operator to the expected state.
+// They are applied once, after the operator is upgraded, and the migrated release is recorded in the FeatureTracker.
+//
 // Each Feature can have a list of cleanup functions. These functions can be particularly useful
-// when the cleanup involves actions other than the removal of resources, such as reverting a patch operation.
+// when the cleanup involves actions other than the removal of resources. Patches applied from manifests
+// are reverted automatically, using the state of the patched fields captured in the FeatureTracker.
 //
 // To create a Feature, use the provided FeatureBuilder. This builder guides through the process
 // using a fluent API.
@@ -49,11 +54,21 @@ type Feature struct {
 
 	data map[string]any
 
-	appliers []resource.Applier
+	dependsOn []string
+
+	retryPolicy RetryPolicy
+	nonBlocking bool
+
+	warningsChecked bool
+	warningsErr     error
+
+	appliers   []resource.Applier
+	migrations []migration
 
 	cleanups          []CleanupFunc
 	clusterOperations []Action
 	preconditions     []Action
+	warnings          []Action
 	postconditions    []Action
 	dataProviders     []Action
 }
@@ -81,6 +96,9 @@ func (f *Feature) Apply(ctx context.Context, cli client.Client) error {
 			return err
 		}
 
+		// warnings can explain why the feature is disabled, e.g. when it requires an optional operator
+		f.checkWarnings(ctx, cli)
+
 		return f.Cleanup(ctx, cli)
 	}
 
@@ -94,64 +112,203 @@ func (f *Feature) Apply(ctx context.Context, cli client.Client) error {
 	}); updateErr != nil {
 		return updateErr
 	}
+	recordTrackerPhase(f, f.tracker, status.PhaseProgressing)
+	f.emitEvent(corev1.EventTypeNormal, EventReasonFeatureStarted, "Applying feature [%s]", f.Name)
 
 	applyErr := f.applyFeature(ctx, cli)
+	recordFailure(f, applyErr)
+	f.emitApplyResultEvent(applyErr)
 	_, reportErr := createFeatureTrackerStatusReporter(cli, f).ReportCondition(ctx, applyErr)
 
 	return multierror.Append(applyErr, reportErr).ErrorOrNil()
 }
 
-func (f *Feature) applyFeature(ctx context.Context, cli client.Client) error {
-	var multiErr *multierror.Error
+// skip reports in the FeatureTracker that the feature has not been applied because some of its dependencies failed.
+func (f *Feature) skip(ctx context.Context, cli client.Client, failedDependencies []string) error {
+	skipErr := &withConditionReasonError{
+		reason: featurev1.ConditionReason.DependencyFailed,
+		err:    fmt.Errorf("skipped applying feature [%s] as its dependencies %v failed", f.Name, failedDependencies),
+	}
 
-	for _, dataProvider := range f.dataProviders {
-		multiErr = multierror.Append(multiErr, dataProvider(ctx, cli, f))
+	if trackerErr := createFeatureTracker(ctx, cli, f); trackerErr != nil {
+		return multierror.Append(skipErr, trackerErr)
 	}
-	if errDataLoad := multiErr.ErrorOrNil(); errDataLoad != nil {
-		return &withConditionReasonError{reason: featurev1.ConditionReason.LoadTemplateData, err: errDataLoad}
+
+	recordFailure(f, skipErr)
+	_, reportErr := createFeatureTrackerStatusReporter(cli, f).ReportCondition(ctx, skipErr)
+
+	return multierror.Append(skipErr, reportErr).ErrorOrNil()
+}
+
+// postpone reports in the FeatureTracker that the feature is waiting for its non-blocking dependencies to become ready.
+func (f *Feature) postpone(ctx context.Context, cli client.Client, pendingDependencies []string) error {
+	postponeErr := &withConditionReasonError{
+		reason: featurev1.ConditionReason.DependencyNotReady,
+		err: &NotReadyError{
+			RequeueAfter: f.retryPolicy.Interval,
+			err:          fmt.Errorf("waiting for dependencies %v of feature [%s] to become ready", pendingDependencies, f.Name),
+		},
 	}
 
-	for _, precondition := range f.preconditions {
-		multiErr = multierror.Append(multiErr, precondition(ctx, cli, f))
+	if trackerErr := createFeatureTracker(ctx, cli, f); trackerErr != nil {
+		return multierror.Append(postponeErr, trackerErr)
 	}
-	if preconditionsErr := multiErr.ErrorOrNil(); preconditionsErr != nil {
-		return &withConditionReasonError{reason: featurev1.ConditionReason.PreConditions, err: preconditionsErr}
+
+	_, reportErr := createFeatureTrackerStatusReporter(cli, f).ReportCondition(ctx, postponeErr)
+
+	return multierror.Append(postponeErr, reportErr).ErrorOrNil()
+}
+
+func (f *Feature) applyFeature(ctx context.Context, cli client.Client) error {
+	defer observeApply(f, phaseTotal)()
+
+	if errDataLoad := f.runPhase(featurev1.ConditionReason.LoadTemplateData, func() error {
+		return f.loadData(ctx, cli)
+	}); errDataLoad != nil {
+		return errDataLoad
+	}
+
+	if preconditionsErr := f.runPhase(featurev1.ConditionReason.PreConditions, func() error {
+		f.checkWarnings(ctx, cli)
+
+		var multiErr *multierror.Error
+		for _, precondition := range f.preconditions {
+			multiErr = multierror.Append(multiErr, precondition(ctx, cli, f))
+		}
+
+		return multiErr.ErrorOrNil()
+	}); preconditionsErr != nil {
+		return preconditionsErr
 	}
 
-	for _, clusterOperation := range f.clusterOperations {
-		if errClusterOperation := clusterOperation(ctx, cli, f); errClusterOperation != nil {
-			return &withConditionReasonError{reason: featurev1.ConditionReason.ResourceCreation, err: errClusterOperation}
+	if errClusterOperation := f.runPhase(featurev1.ConditionReason.ResourceCreation, func() error {
+		for _, clusterOperation := range f.clusterOperations {
+			if errClusterOperation := clusterOperation(ctx, cli, f); errClusterOperation != nil {
+				return errClusterOperation
+			}
 		}
+
+		return nil
+	}); errClusterOperation != nil {
+		return errClusterOperation
 	}
 
-	for i := range f.appliers {
-		r := f.appliers[i]
-		if processErr := r.Apply(ctx, cli, f.data, DefaultMetaOptions(f)...); processErr != nil {
-			return &withConditionReasonError{reason: featurev1.ConditionReason.ApplyManifests, err: processErr}
+	if processErr := f.runPhase(featurev1.ConditionReason.ApplyManifests, func() error {
+		applied := &inventory{}
+		metaOptions := append(DefaultMetaOptions(f), applied.Record)
+		// conflicts with other field managers do not fail the feature, but are reported as its warnings
+		applyCtx, conflicts := cluster.WithConflictRecorder(ctx)
+		for i := range f.appliers {
+			r := f.appliers[i]
+			if snapshotter, canSnapshot := r.(resource.Snapshotter); canSnapshot {
+				if snapshotErr := f.recordPatchSnapshots(ctx, cli, snapshotter); snapshotErr != nil {
+					return snapshotErr
+				}
+			}
+
+			if processErr := r.Apply(applyCtx, cli, f.data, metaOptions...); processErr != nil {
+				return processErr
+			}
 		}
+		f.addWarning(conflicts.Err())
+
+		return f.updateInventory(ctx, cli, applied.references)
+	}); processErr != nil {
+		return processErr
 	}
 
-	for _, postcondition := range f.postconditions {
-		multiErr = multierror.Append(multiErr, postcondition(ctx, cli, f))
+	if len(f.appliers) > 0 {
+		f.emitEvent(corev1.EventTypeNormal, EventReasonManifestsApplied, "Applied manifests of feature [%s]", f.Name)
 	}
-	if postConditionErr := multiErr.ErrorOrNil(); postConditionErr != nil {
-		return &withConditionReasonError{reason: featurev1.ConditionReason.PostConditions, err: postConditionErr}
+
+	if migrationErr := f.runPhase(featurev1.ConditionReason.Migrations, func() error {
+		return f.migrate(ctx, cli)
+	}); migrationErr != nil {
+		return migrationErr
+	}
+
+	return f.runPhase(featurev1.ConditionReason.PostConditions, func() error {
+		var multiErr *multierror.Error
+		for _, postcondition := range f.postconditions {
+			multiErr = multierror.Append(multiErr, postcondition(ctx, cli, f))
+		}
+
+		return multiErr.ErrorOrNil()
+	})
+}
+
+// runPhase invokes a step of applying the feature, measuring its duration and attributing its failure to the given reason.
+func (f *Feature) runPhase(reason featurev1.FeatureConditionReason, step func() error) error {
+	defer observeApply(f, string(reason))()
+
+	if err := step(); err != nil {
+		return &withConditionReasonError{reason: reason, err: err}
 	}
 
 	return nil
 }
 
+// loadData invokes all data providers of the feature, so the data can be used in templates and actions.
+func (f *Feature) loadData(ctx context.Context, cli client.Client) error {
+	var multiErr *multierror.Error
+	for _, dataProvider := range f.dataProviders {
+		multiErr = multierror.Append(multiErr, dataProvider(ctx, cli, f))
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
 func (f *Feature) Cleanup(ctx context.Context, cli client.Client) error {
-	// Ensure associated FeatureTracker instance has been removed as last one
-	// in the chain of cleanups.
-	f.addCleanup(removeFeatureTracker(f))
+	defer observeCleanup(f)()
+
+	if f.tracker == nil {
+		tracker, errGet := getFeatureTracker(ctx, cli, f.Name, f.TargetNamespace)
+		if client.IgnoreNotFound(errGet) != nil {
+			return errGet
+		}
+
+		f.tracker = tracker
+	}
+	// Disabled features are cleaned up on every reconciliation, so only removal of previously applied feature is reported.
+	applied := f.tracker != nil
+
+	// Ensure patches are reverted using snapshots stored in the associated FeatureTracker
+	// before the FeatureTracker instance is removed as last one in the chain of cleanups.
+	f.addCleanup(revertPatches(f), removeFeatureTracker(f))
 
 	var cleanupErrors *multierror.Error
 	for _, cleanupFunc := range f.cleanups {
 		cleanupErrors = multierror.Append(cleanupErrors, cleanupFunc(ctx, cli))
 	}
 
-	return cleanupErrors.ErrorOrNil()
+	if cleanupErr := cleanupErrors.ErrorOrNil(); cleanupErr != nil {
+		return cleanupErr
+	}
+
+	forgetTracker(f)
+	if applied {
+		f.emitEvent(corev1.EventTypeNormal, EventReasonCleanupDone, "Removed feature [%s]", f.Name)
+	}
+
+	return nil
+}
+
+// applyOnCleanup creates a CleanupFunc which applies given manifests, e.g. inverse patches, using the feature data.
+// Missing resources are skipped, as there is nothing left to revert.
+func applyOnCleanup(f *Feature, appliers []resource.Applier) CleanupFunc {
+	return func(ctx context.Context, cli client.Client) error {
+		if errDataLoad := f.loadData(ctx, cli); errDataLoad != nil {
+			return fmt.Errorf("failed loading data for cleanup of feature '%s': %w", f.Name, errDataLoad)
+		}
+
+		for i := range appliers {
+			if errApply := appliers[i].Apply(ctx, cli, f.data); client.IgnoreNotFound(errApply) != nil {
+				return fmt.Errorf("failed applying cleanup manifests of feature '%s': %w", f.Name, errApply)
+			}
+		}
+
+		return nil
+	}
 }
 
 func (f *Feature) addCleanup(cleanupFuncs ...CleanupFunc) {
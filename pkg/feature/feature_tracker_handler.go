@@ -31,12 +31,34 @@ func (e *withConditionReasonError) Error() string {
 // createFeatureTracker creates a FeatureTracker, persists it in the cluster,
 // and attaches it to the provided Feature instance.
 func createFeatureTracker(ctx context.Context, cli client.Client, f *Feature) error {
+	created, errTracker := getOrCreateFeatureTracker(ctx, cli, f)
+	if errTracker != nil || !created {
+		return errTracker
+	}
+
+	// Fresh installation is already in the state expected by the current release, so there is nothing to migrate.
+	migrated, errUpdate := status.UpdateWithRetry(ctx, cli, f.tracker, func(saved *featurev1.FeatureTracker) {
+		saved.Status.MigratedVersion = currentRelease().String()
+	})
+	if errUpdate != nil {
+		return fmt.Errorf("failed recording migrated version of FeatureTracker %s: %w", f.tracker.Name, errUpdate)
+	}
+
+	f.tracker.Status.MigratedVersion = migrated.Status.MigratedVersion
+
+	return nil
+}
+
+// getOrCreateFeatureTracker attaches the FeatureTracker of the Feature, creating it when it does not exist in the cluster yet.
+// It does not update the status of the created FeatureTracker, so it can be used with dry-run client, which persists nothing.
+func getOrCreateFeatureTracker(ctx context.Context, cli client.Client, f *Feature) (bool, error) {
 	tracker, errGet := getFeatureTracker(ctx, cli, f.Name, f.TargetNamespace)
 	if client.IgnoreNotFound(errGet) != nil {
-		return errGet
+		return false, errGet
 	}
 
-	if k8serr.IsNotFound(errGet) {
+	created := k8serr.IsNotFound(errGet)
+	if created {
 		tracker = featurev1.NewFeatureTracker(f.Name, f.TargetNamespace)
 		tracker.Spec = featurev1.FeatureTrackerSpec{
 			Source:       *f.source,
@@ -45,22 +67,22 @@ func createFeatureTracker(ctx context.Context, cli client.Client, f *Feature) er
 		if f.owner != nil {
 			ownerRef := cluster.OwnedBy(f.owner, cli.Scheme())
 			if errMetaOpts := cluster.ApplyMetaOptions(tracker, ownerRef); errMetaOpts != nil {
-				return fmt.Errorf("failed adding owner to FeatureTracker %s: %w", tracker.Name, errMetaOpts)
+				return false, fmt.Errorf("failed adding owner to FeatureTracker %s: %w", tracker.Name, errMetaOpts)
 			}
 		}
 
 		if errCreate := cli.Create(ctx, tracker); errCreate != nil {
-			return fmt.Errorf("failed creating FeatureTracker %s: %w", tracker.Name, errCreate)
+			return false, fmt.Errorf("failed creating FeatureTracker %s: %w", tracker.Name, errCreate)
 		}
 	}
 
 	if errGVK := ensureGVKSet(tracker, cli.Scheme()); errGVK != nil {
-		return fmt.Errorf("failed ensuring GVK is set for %s: %w", tracker.Name, errGVK)
+		return false, fmt.Errorf("failed ensuring GVK is set for %s: %w", tracker.Name, errGVK)
 	}
 
 	f.tracker = tracker
 
-	return nil
+	return created, nil
 }
 
 // removeFeatureTracker removes the FeatureTracker associated with the provided Feature instance if one exists in the cluster.
@@ -114,19 +136,30 @@ func createFeatureTrackerStatusReporter(cli client.Client, f *Feature) *status.R
 		updatedCondition := func(saved *featurev1.FeatureTracker) {
 			status.SetCompleteCondition(&saved.Status.Conditions, string(featurev1.ConditionReason.FeatureCreated), fmt.Sprintf("Applied feature [%s] successfully", f.Name))
 			saved.Status.Phase = status.PhaseReady
+			recordTrackerPhase(f, saved, saved.Status.Phase)
 		}
 		if err != nil {
-			reason := featurev1.ConditionReason.FailedApplying // generic reason when error is not related to any specific step of the feature apply
-			var conditionErr *withConditionReasonError
-			if errors.As(err, &conditionErr) {
-				reason = conditionErr.reason
-			}
+			reason := reasonOf(err)
 			updatedCondition = func(saved *featurev1.FeatureTracker) {
 				status.SetErrorCondition(&saved.Status.Conditions, string(reason), fmt.Sprintf("Failed applying [%s]: %+v", f.Name, err))
 				saved.Status.Phase = status.PhaseError
+				recordTrackerPhase(f, saved, saved.Status.Phase)
+			}
+			if _, notReady := IsNotReady(err); notReady {
+				updatedCondition = func(saved *featurev1.FeatureTracker) {
+					status.SetProgressingCondition(&saved.Status.Conditions, string(reason), fmt.Sprintf("Applying feature [%s]: %+v", f.Name, err))
+					saved.Status.Phase = status.PhaseProgressing
+					recordTrackerPhase(f, saved, saved.Status.Phase)
+				}
 			}
 		}
 
-		return updatedCondition
+		return func(saved *featurev1.FeatureTracker) {
+			updatedCondition(saved)
+			// Degraded condition of the failed feature reports its failure, which takes precedence over warnings
+			if err == nil {
+				f.reportWarnings(&saved.Status.Conditions)
+			}
+		}
 	})
 }
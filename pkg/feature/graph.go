@@ -0,0 +1,128 @@
+package feature
+
+import (
+	"fmt"
+	"strings"
+)
+
+// featureGraph is a directed acyclic graph of features, where each feature points to the features it depends on.
+// It is used by FeaturesHandler to determine in which order features can be applied and removed.
+type featureGraph struct {
+	features []*Feature
+	byName   map[string]*Feature
+}
+
+// newFeatureGraph builds the dependency graph for the given features.
+// It fails when a feature depends on a feature which is not part of the graph or when dependencies form a cycle.
+func newFeatureGraph(features []*Feature) (*featureGraph, error) {
+	byName := make(map[string]*Feature, len(features))
+	for _, f := range features {
+		if _, exists := byName[f.Name]; exists {
+			return nil, fmt.Errorf("feature '%s' is defined more than once", f.Name)
+		}
+		byName[f.Name] = f
+	}
+
+	for _, f := range features {
+		for _, dependency := range f.dependsOn {
+			if _, exists := byName[dependency]; !exists {
+				return nil, fmt.Errorf("feature '%s' depends on undefined feature '%s'", f.Name, dependency)
+			}
+		}
+	}
+
+	if cycleErr := detectCycle(features); cycleErr != nil {
+		return nil, cycleErr
+	}
+
+	return &featureGraph{
+		features: features,
+		byName:   byName,
+	}, nil
+}
+
+// topologicalOrder returns features sorted so that every feature comes after all of its dependencies.
+// Features which do not depend on each other keep the order in which they have been registered.
+func (g *featureGraph) topologicalOrder() []*Feature {
+	ordered := make([]*Feature, 0, len(g.features))
+	visited := make(map[string]bool, len(g.features))
+
+	var visit func(f *Feature)
+	visit = func(f *Feature) {
+		if visited[f.Name] {
+			return
+		}
+		visited[f.Name] = true
+
+		for _, dependency := range f.dependsOn {
+			visit(g.byName[dependency])
+		}
+
+		ordered = append(ordered, f)
+	}
+
+	for _, f := range g.features {
+		visit(f)
+	}
+
+	return ordered
+}
+
+// detectCycle checks if dependencies between given features form a cycle.
+// Dependencies on features which are not part of the given list are ignored.
+func detectCycle(features []*Feature) error {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	byName := make(map[string]*Feature, len(features))
+	for _, f := range features {
+		byName[f.Name] = f
+	}
+
+	state := make(map[string]int, len(features))
+	var path []string
+
+	var visit func(f *Feature) error
+	visit = func(f *Feature) error {
+		switch state[f.Name] {
+		case done:
+			return nil
+		case inProgress:
+			cycleStart := 0
+			for i, name := range path {
+				if name == f.Name {
+					cycleStart = i
+				}
+			}
+
+			return fmt.Errorf("dependency cycle detected between features: %s", strings.Join(append(path[cycleStart:], f.Name), " -> "))
+		}
+
+		state[f.Name] = inProgress
+		path = append(path, f.Name)
+
+		for _, dependency := range f.dependsOn {
+			if dependencyFeature, exists := byName[dependency]; exists {
+				if err := visit(dependencyFeature); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[f.Name] = done
+
+		return nil
+	}
+
+	for _, f := range features {
+		if err := visit(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,9 +4,11 @@ package feature
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/hashicorp/go-multierror"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
@@ -14,6 +16,9 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 )
 
+// maxConcurrentFeatures limits how many features of a handler are applied at the same time.
+const maxConcurrentFeatures = 4
+
 type featuresHandler interface {
 	Apply(ctx context.Context, cli client.Client) error
 	Delete(ctx context.Context, cli client.Client) error
@@ -39,6 +44,7 @@ var _ FeaturesRegistry = (*FeaturesHandler)(nil)
 
 // Add loads features defined by passed builders and adds to internal list which is then used to Apply on the cluster.
 // It also makes sure that both TargetNamespace and Source are added to the feature before it's `Create()`ed.
+// Features which form a dependency cycle with already added ones are rejected.
 func (fh *FeaturesHandler) Add(builders ...*featureBuilder) error {
 	var multiErr *multierror.Error
 
@@ -49,13 +55,26 @@ func (fh *FeaturesHandler) Add(builders ...*featureBuilder) error {
 			OwnedBy(fh.owner).
 			Source(fh.source).
 			Create()
-		multiErr = multierror.Append(multiErr, err)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+
+			continue
+		}
+
 		fh.features = append(fh.features, feature)
 	}
 
+	if cycleErr := detectCycle(fh.features); cycleErr != nil {
+		multiErr = multierror.Append(multiErr, cycleErr)
+	}
+
 	return multiErr.ErrorOrNil()
 }
 
+// Apply applies all features registered by the handler's providers.
+// Features are applied concurrently, up to maxConcurrentFeatures at a time, as soon as all the features they depend on are applied.
+// When any of the dependencies fails, the dependent feature is skipped and this is reported in its FeatureTracker.
+// When any of the dependencies is not ready yet (see NonBlocking), the dependent feature is reported as progressing.
 func (fh *FeaturesHandler) Apply(ctx context.Context, cli client.Client) error {
 	fh.features = make([]*Feature, 0)
 
@@ -65,9 +84,65 @@ func (fh *FeaturesHandler) Apply(ctx context.Context, cli client.Client) error {
 		}
 	}
 
+	if _, graphErr := newFeatureGraph(fh.features); graphErr != nil {
+		return fmt.Errorf("failed resolving dependencies between features. cause: %w", graphErr)
+	}
+
+	if len(fh.features) == 0 {
+		return nil
+	}
+
+	results := make(map[string]*featureResult, len(fh.features))
+	pending := make(map[string]int, len(fh.features))
+	dependents := make(map[string][]*Feature, len(fh.features))
+	ready := make(chan *Feature, len(fh.features))
+	for _, f := range fh.features {
+		results[f.Name] = &featureResult{}
+
+		dependencies := sets.New(f.dependsOn...)
+		pending[f.Name] = dependencies.Len()
+		for dependency := range dependencies {
+			dependents[dependency] = append(dependents[dependency], f)
+		}
+		if dependencies.Len() == 0 {
+			ready <- f
+		}
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		processed int
+	)
+	for i := 0; i < min(maxConcurrentFeatures, len(fh.features)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for f := range ready {
+				results[f.Name].err = applyFeature(ctx, cli, f, results)
+
+				// dependents are scheduled once all the features they depend on have been processed
+				mu.Lock()
+				processed++
+				for _, dependent := range dependents[f.Name] {
+					pending[dependent.Name]--
+					if pending[dependent.Name] == 0 {
+						ready <- dependent
+					}
+				}
+				if processed == len(fh.features) {
+					close(ready)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
 	var multiErr *multierror.Error
 	for _, f := range fh.features {
-		if applyErr := f.Apply(ctx, cli); applyErr != nil {
+		if applyErr := results[f.Name].err; applyErr != nil {
 			multiErr = multierror.Append(multiErr, fmt.Errorf("failed applying FeatureHandler features. cause: %w", applyErr))
 		}
 	}
@@ -75,8 +150,25 @@ func (fh *FeaturesHandler) Apply(ctx context.Context, cli client.Client) error {
 	return multiErr.ErrorOrNil()
 }
 
-// Delete executes registered clean-up tasks for handled Features in the opposite order they were initiated.
-// This approach assumes that Features are either instantiated in the correct sequence or are self-contained.
+// warnings collects failed warning checks of the features applied by the handler.
+func (fh *FeaturesHandler) warnings() error {
+	var multiErr *multierror.Error
+	for _, f := range fh.features {
+		if f.warningsErr != nil {
+			multiErr = multierror.Append(multiErr, f.warningsErr)
+		}
+	}
+
+	if warningsErr := multiErr.ErrorOrNil(); warningsErr != nil {
+		return &WarningError{err: warningsErr}
+	}
+
+	return nil
+}
+
+// Delete executes registered clean-up tasks for handled Features in the opposite order of their dependencies,
+// so that a feature is always cleaned up before the features it depends on.
+// Features which do not depend on each other are cleaned up in the opposite order they were registered.
 func (fh *FeaturesHandler) Delete(ctx context.Context, cli client.Client) error {
 	fh.features = make([]*Feature, 0)
 
@@ -86,9 +178,16 @@ func (fh *FeaturesHandler) Delete(ctx context.Context, cli client.Client) error
 		}
 	}
 
+	graph, graphErr := newFeatureGraph(fh.features)
+	if graphErr != nil {
+		return fmt.Errorf("failed resolving dependencies between features. cause: %w", graphErr)
+	}
+
+	ordered := graph.topologicalOrder()
+
 	var multiErr *multierror.Error
-	for i := len(fh.features) - 1; i >= 0; i-- {
-		if cleanupErr := fh.features[i].Cleanup(ctx, cli); cleanupErr != nil {
+	for i := len(ordered) - 1; i >= 0; i-- {
+		if cleanupErr := ordered[i].Cleanup(ctx, cli); cleanupErr != nil {
 			multiErr = multierror.Append(multiErr, fmt.Errorf("failed executing cleanup in FeatureHandler. cause: %w", cleanupErr))
 		}
 	}
@@ -96,6 +195,38 @@ func (fh *FeaturesHandler) Delete(ctx context.Context, cli client.Client) error
 	return multiErr.ErrorOrNil()
 }
 
+// featureResult holds the outcome of applying a feature.
+type featureResult struct {
+	err error
+}
+
+// applyFeature applies the feature, unless any of the features it depends on, which have already been processed, failed or is not ready yet.
+func applyFeature(ctx context.Context, cli client.Client, f *Feature, results map[string]*featureResult) error {
+	var failedDependencies, pendingDependencies []string
+	for _, dependency := range f.dependsOn {
+		dependencyErr := results[dependency].err
+		if dependencyErr == nil {
+			continue
+		}
+
+		if _, notReady := IsNotReady(dependencyErr); notReady {
+			pendingDependencies = append(pendingDependencies, dependency)
+		} else {
+			failedDependencies = append(failedDependencies, dependency)
+		}
+	}
+
+	if len(failedDependencies) > 0 {
+		return f.skip(ctx, cli, failedDependencies)
+	}
+
+	if len(pendingDependencies) > 0 {
+		return f.postpone(ctx, cli, pendingDependencies)
+	}
+
+	return f.Apply(ctx, cli)
+}
+
 // FeaturesProvider is a function which allow to define list of features
 // and add them to the handler's registry.
 type FeaturesProvider func(registry FeaturesRegistry) error
@@ -118,6 +249,16 @@ func ComponentFeaturesHandler(owner metav1.Object, componentName, targetNamespac
 	}
 }
 
+// CustomResourceFeaturesHandler creates handler for features declared using Feature custom resource, which becomes their owner.
+func CustomResourceFeaturesHandler(owner *featurev1.Feature, targetNamespace string, def ...FeaturesProvider) *FeaturesHandler {
+	return &FeaturesHandler{
+		owner:             owner,
+		targetNamespace:   targetNamespace,
+		source:            featurev1.Source{Type: featurev1.FeatureType, Name: owner.Name},
+		featuresProviders: def,
+	}
+}
+
 // EmptyFeaturesHandler is noop handler so that we can avoid nil checks in the code and safely call Apply/Delete methods.
 var EmptyFeaturesHandler = &FeaturesHandler{
 	features:          []*Feature{},
@@ -140,9 +281,15 @@ func NewHandlerWithReporter[T client.Object](handler *FeaturesHandler, reporter
 	}
 }
 
+// Apply applies the features and reports the outcome. When all the features are applied, but some of their
+// warning checks failed, the reporter receives WarningError while Apply itself succeeds.
 func (h HandlerWithReporter[T]) Apply(ctx context.Context, cli client.Client) error {
 	applyErr := h.handler.Apply(ctx, cli)
-	_, reportErr := h.reporter.ReportCondition(ctx, applyErr)
+	reportedErr := applyErr
+	if applyErr == nil {
+		reportedErr = h.handler.warnings()
+	}
+	_, reportErr := h.reporter.ReportCondition(ctx, reportedErr)
 	// We could have failed during Apply phase as well as during reporting.
 	// We should return both errors to the caller.
 	return multierror.Append(applyErr, reportErr).ErrorOrNil()
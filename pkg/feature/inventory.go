@@ -0,0 +1,125 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+)
+
+// inventory collects references to the objects created from the feature manifests.
+type inventory struct {
+	references []featurev1.ResourceReference
+}
+
+// Record can be used as cluster.MetaOptions to add every processed object to the inventory.
+func (i *inventory) Record(obj metav1.Object) error {
+	runtimeObj, isRuntimeObj := obj.(runtime.Object)
+	if !isRuntimeObj {
+		return fmt.Errorf("unable to determine kind of %s/%s", obj.GetNamespace(), obj.GetName())
+	}
+
+	gvk := runtimeObj.GetObjectKind().GroupVersionKind()
+	reference := featurev1.ResourceReference{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	if !containsReference(i.references, reference) {
+		i.references = append(i.references, reference)
+	}
+
+	return nil
+}
+
+// updateInventory removes objects listed in the FeatureTracker inventory which are no longer created by the feature,
+// and stores the current list of the objects in the FeatureTracker status.
+func (f *Feature) updateInventory(ctx context.Context, cli client.Client, current []featurev1.ResourceReference) error {
+	var pruneErrors *multierror.Error
+	for _, previous := range f.tracker.Status.Inventory {
+		if containsReference(current, previous) {
+			continue
+		}
+
+		pruneErrors = multierror.Append(pruneErrors, f.prune(ctx, cli, previous))
+	}
+
+	if pruneErr := pruneErrors.ErrorOrNil(); pruneErr != nil {
+		return fmt.Errorf("failed removing objects which are no longer part of the feature: %w", pruneErr)
+	}
+
+	updated, updateErr := status.UpdateWithRetry(ctx, cli, f.tracker, func(saved *featurev1.FeatureTracker) {
+		saved.Status.Inventory = current
+	})
+	if updateErr != nil {
+		return fmt.Errorf("failed updating inventory of FeatureTracker %s: %w", f.tracker.Name, updateErr)
+	}
+
+	f.tracker.Status.Inventory = updated.Status.Inventory
+
+	return nil
+}
+
+// prune deletes the referenced object, but only if it is still owned by the feature.
+func (f *Feature) prune(ctx context.Context, cli client.Client, reference featurev1.ResourceReference) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(metav1.GroupVersion{Group: reference.Group, Version: reference.Version}.String())
+	obj.SetKind(reference.Kind)
+
+	errGet := cli.Get(ctx, client.ObjectKey{Namespace: reference.Namespace, Name: reference.Name}, obj)
+	if k8serr.IsNotFound(errGet) || meta.IsNoMatchError(errGet) {
+		return nil
+	}
+	if errGet != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", reference.Kind, reference.Namespace, reference.Name, errGet)
+	}
+
+	if !isOwnedBy(obj, f.tracker) {
+		f.Log.Info("skipping removal of object which is not owned by the feature", "kind", reference.Kind, "namespace", reference.Namespace, "name", reference.Name)
+
+		return nil
+	}
+
+	f.Log.Info("removing object which is no longer part of the feature", "kind", reference.Kind, "namespace", reference.Namespace, "name", reference.Name)
+
+	if errDelete := cli.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(errDelete) != nil {
+		return fmt.Errorf("failed to delete %s %s/%s: %w", reference.Kind, reference.Namespace, reference.Name, errDelete)
+	}
+
+	return nil
+}
+
+func isOwnedBy(obj metav1.Object, tracker *featurev1.FeatureTracker) bool {
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.UID == tracker.UID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsReference checks if the object is listed in the references. API version is not taken into account,
+// as the same object can be served using different versions of its API.
+func containsReference(references []featurev1.ResourceReference, reference featurev1.ResourceReference) bool {
+	for _, ref := range references {
+		if ref.Group == reference.Group && ref.Kind == reference.Kind &&
+			ref.Namespace == reference.Namespace && ref.Name == reference.Name {
+			return true
+		}
+	}
+
+	return false
+}
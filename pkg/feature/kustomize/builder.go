@@ -0,0 +1,44 @@
+package kustomize
+
+import (
+	"io/fs"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+)
+
+type Builder struct {
+	location fs.FS
+	paths    []string
+	plugins  []resmap.Transformer
+}
+
+// Location sets the root file system from which kustomization paths are loaded.
+func Location(fsys fs.FS) *Builder {
+	return &Builder{location: fsys}
+}
+
+// Path adds kustomization directories to be rendered. If a given directory does not contain
+// a kustomization file, its "default" overlay is used instead.
+func (b *Builder) Path(paths ...string) *Builder {
+	b.paths = append(b.paths, paths...)
+
+	return b
+}
+
+// WithPlugins adds transformers which are applied to the rendered resources, such as those defined in plugins package.
+func (b *Builder) WithPlugins(plugins ...resmap.Transformer) *Builder {
+	b.plugins = append(b.plugins, plugins...)
+
+	return b
+}
+
+func (b *Builder) Create() ([]resource.Applier, error) {
+	appliers := make([]resource.Applier, 0, len(b.paths))
+	for _, path := range b.paths {
+		appliers = append(appliers, createApplier(Create(b.location, path, b.plugins...)))
+	}
+
+	return appliers, nil
+}
@@ -0,0 +1,13 @@
+package kustomize_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestKustomize(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kustomize Suite")
+}
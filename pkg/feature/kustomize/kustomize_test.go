@@ -0,0 +1,86 @@
+package kustomize_test
+
+import (
+	"testing/fstest"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/kustomize"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kustomization rendering", func() {
+
+	var fsys fstest.MapFS
+
+	BeforeEach(func() {
+		fsys = fstest.MapFS{
+			"manifests/base/kustomization.yaml": &fstest.MapFile{Data: []byte(`
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - configmap.yaml
+`)},
+			"manifests/base/configmap.yaml": &fstest.MapFile{Data: []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-configmap
+data:
+  key: value
+`)},
+			"manifests/overlay/default/kustomization.yaml": &fstest.MapFile{Data: []byte(`
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - ../../base
+namePrefix: overlay-
+`)},
+		}
+	})
+
+	It("should render resources from the kustomization", func() {
+		// when
+		objs, err := kustomize.Create(fsys, "manifests/base").Render()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+		Expect(objs[0].GetKind()).To(Equal("ConfigMap"))
+		Expect(objs[0].GetName()).To(Equal("my-configmap"))
+	})
+
+	It("should use default overlay when path does not contain kustomization", func() {
+		// when
+		objs, err := kustomize.Create(fsys, "manifests/overlay").Render()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+		Expect(objs[0].GetName()).To(Equal("overlay-my-configmap"))
+	})
+
+	It("should apply namespace and labels plugins to rendered resources", func() {
+		// when
+		objs, err := kustomize.Create(fsys, "manifests/base",
+			plugins.CreateNamespaceApplierPlugin("test-namespace"),
+			plugins.CreateAddLabelsPlugin("test-component"),
+		).Render()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+		Expect(objs[0].GetNamespace()).To(Equal("test-namespace"))
+		Expect(objs[0].GetLabels()).To(HaveKeyWithValue(labels.ODH.Component("test-component"), "true"))
+	})
+
+	It("should fail when kustomization does not exist", func() {
+		// when
+		_, err := kustomize.Create(fsys, "manifests/not-existing").Render()
+
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
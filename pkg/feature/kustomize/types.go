@@ -0,0 +1,140 @@
+package kustomize
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/conversion"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+)
+
+// Kustomization represents a kustomize package which is rendered using krusty.
+type Kustomization struct {
+	path    string
+	fsys    fs.FS
+	plugins []resmap.Transformer
+}
+
+func Create(fsys fs.FS, path string, plugins ...resmap.Transformer) *Kustomization {
+	return &Kustomization{
+		path:    path,
+		fsys:    fsys,
+		plugins: plugins,
+	}
+}
+
+// Render builds the kustomization and applies configured plugins to its output.
+func (k *Kustomization) Render() ([]*unstructured.Unstructured, error) {
+	inMemFS, errCopy := copyToInMemoryFs(k.fsys)
+	if errCopy != nil {
+		return nil, fmt.Errorf("failed loading kustomization %s: %w", k.path, errCopy)
+	}
+
+	kustomizationPath := k.path
+	if !hasKustomization(k.fsys, kustomizationPath) {
+		kustomizationPath = path.Join(kustomizationPath, "default")
+	}
+
+	resMap, errRun := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(inMemFS, kustomizationPath)
+	if errRun != nil {
+		return nil, fmt.Errorf("failed rendering kustomization %s: %w", kustomizationPath, errRun)
+	}
+
+	for _, plugin := range k.plugins {
+		if errTransform := plugin.Transform(resMap); errTransform != nil {
+			return nil, fmt.Errorf("failed applying plugin to kustomization %s: %w", kustomizationPath, errTransform)
+		}
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, resMap.Size())
+	for _, res := range resMap.Resources() {
+		obj, errConvert := conversion.ResourceToUnstructured(res)
+		if errConvert != nil {
+			return nil, fmt.Errorf("failed converting %s rendered from kustomization %s: %w", res.CurId(), kustomizationPath, errConvert)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// Applier wraps an instance of Kustomization and provides a way to apply it to the cluster.
+type Applier struct {
+	kustomization *Kustomization
+}
+
+var _ resource.Applier = (*Applier)(nil)
+var _ resource.Planner = (*Applier)(nil)
+
+func createApplier(kustomization *Kustomization) *Applier {
+	return &Applier{
+		kustomization: kustomization,
+	}
+}
+
+// Apply renders owned kustomization and applies resulting resources to a cluster.
+func (a Applier) Apply(ctx context.Context, cli client.Client, _ map[string]any, options ...cluster.MetaOptions) error {
+	objects, errRender := a.kustomization.Render()
+	if errRender != nil {
+		return errRender
+	}
+
+	return resource.Apply(ctx, cli, objects, options...)
+}
+
+// Plan renders owned kustomization and computes changes applying it would introduce to the cluster
+// using server-side dry-run. Nothing is persisted in the cluster.
+func (a Applier) Plan(ctx context.Context, cli client.Client, _ map[string]any, options ...cluster.MetaOptions) ([]resource.Change, error) {
+	objects, errRender := a.kustomization.Render()
+	if errRender != nil {
+		return nil, errRender
+	}
+
+	return resource.PlanApply(ctx, cli, objects, options...)
+}
+
+func hasKustomization(fsys fs.FS, dir string) bool {
+	for _, kustomizationFile := range konfig.RecognizedKustomizationFileNames() {
+		if _, err := fs.Stat(fsys, path.Join(dir, kustomizationFile)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyToInMemoryFs copies the content of the given fs.FS to the in-memory file system understood by kustomize.
+// The whole file system is copied, as kustomizations can refer to resources outside of their own directory.
+func copyToInMemoryFs(fsys fs.FS) (filesys.FileSystem, error) {
+	inMemFS := filesys.MakeFsInMemory()
+
+	err := fs.WalkDir(fsys, ".", func(filePath string, dirEntry fs.DirEntry, errWalk error) error {
+		if errWalk != nil {
+			return errWalk
+		}
+
+		if dirEntry.IsDir() {
+			return inMemFS.MkdirAll(filePath)
+		}
+
+		content, errRead := fs.ReadFile(fsys, filePath)
+		if errRead != nil {
+			return errRead
+		}
+
+		return inMemFS.WriteFile(filePath, content)
+	})
+
+	return inMemFS, err
+}
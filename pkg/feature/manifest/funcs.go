@@ -0,0 +1,130 @@
+package manifest
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+// templateFuncs returns functions available in the templated manifests.
+// The data passed to the template is used to resolve lookups done using the "data" function.
+func templateFuncs(data any) template.FuncMap {
+	return template.FuncMap{
+		"default":  defaultValue,
+		"quote":    quote,
+		"toYaml":   toYaml,
+		"indent":   indent,
+		"b64enc":   b64enc,
+		"required": required,
+		"hasKey":   hasKey,
+		"data": func(key string) (any, error) {
+			return lookup(data, key)
+		},
+	}
+}
+
+// defaultValue returns given value, or the default one when the value is empty.
+//
+//	{{ .ControlPlane.Namespace | default "istio-system" }}
+//
+// The default applies both to missing keys and to keys with empty values. Note that Validate renders templates
+// with missingkey=error, so embedded templates referring to optional keys should guard them using hasKey:
+//
+//	{{ if hasKey . "Optional" }}{{ .Optional }}{{ else }}fallback{{ end }}
+func defaultValue(defaultVal any, given ...any) any {
+	if len(given) == 0 || isEmpty(given[0]) {
+		return defaultVal
+	}
+
+	return given[0]
+}
+
+// quote wraps the string representation of the value in double quotes, escaping it if needed.
+func quote(value any) string {
+	if value == nil {
+		return `""`
+	}
+
+	return strconv.Quote(fmt.Sprint(value))
+}
+
+// toYaml serializes the value to YAML. The trailing newline is removed, so it can be combined with indent.
+func toYaml(value any) (string, error) {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize value to YAML: %w", err)
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// indent prefixes every line of the text with given number of spaces.
+func indent(spaces int, text string) string {
+	padding := strings.Repeat(" ", spaces)
+
+	return padding + strings.ReplaceAll(text, "\n", "\n"+padding)
+}
+
+// b64enc encodes the string representation of the value using standard base64 encoding.
+func b64enc(value any) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(value)))
+}
+
+// required fails rendering of the template with the given message when the value is empty.
+//
+//	{{ required "audiences must be defined" .Auth.Audiences }}
+func required(message string, value any) (any, error) {
+	if isEmpty(value) {
+		return nil, errors.New(message)
+	}
+
+	return value, nil
+}
+
+// hasKey checks if the map contains given key.
+func hasKey(container any, key string) (bool, error) {
+	value := reflect.ValueOf(container)
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return false, fmt.Errorf("hasKey expects a map with string keys, got %T", container)
+	}
+
+	return value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key())).IsValid(), nil
+}
+
+// lookup retrieves the value stored under given key in the data passed to the template.
+// It allows accessing keys which are not valid identifiers, e.g. {{ data "some-key" }}.
+func lookup(data any, key string) (any, error) {
+	dataMap, isMap := data.(map[string]any)
+	if !isMap {
+		return nil, fmt.Errorf("data lookup is not supported for %T", data)
+	}
+
+	value, found := dataMap[key]
+	if !found {
+		return nil, fmt.Errorf("key %s not found", key)
+	}
+
+	return value, nil
+}
+
+func isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() { //nolint:exhaustive // Reason: all the other kinds are compared to their zero value
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
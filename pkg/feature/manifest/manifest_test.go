@@ -71,10 +71,10 @@ data:
 			Expect(err).ToNot(HaveOccurred())
 		})
 
-		It("should fail when template refers to non existing key", func() {
+		It("should fail when template requires non existing key", func() {
 			// given
 			pathToBrokenTpl := filepath.Join("broken", path)
-			Expect(afero.WriteFile(inMemFS.Fs, pathToBrokenTpl, []byte(resourceYaml+"\n {{ .NotExistingKey }}"), 0644)).To(Succeed())
+			Expect(afero.WriteFile(inMemFS.Fs, pathToBrokenTpl, []byte(resourceYaml+"\n {{ required \"NotExistingKey must be defined\" .NotExistingKey }}"), 0644)).To(Succeed())
 			data := map[string]string{
 				"TargetNamespace": "template-ns",
 			}
@@ -84,7 +84,7 @@ data:
 			_, err := manifest.Process(data)
 
 			// then
-			Expect(err).Should(MatchError(ContainSubstring("at <.NotExistingKey>: map has no entry for key")))
+			Expect(err).Should(MatchError(ContainSubstring("NotExistingKey must be defined")))
 		})
 
 		It("should substitute target namespace in the templated manifest", func() {
@@ -104,6 +104,137 @@ data:
 			Expect(objs[0].GetNamespace()).To(Equal("template-ns"))
 		})
 
+		It("should point to the template file and line when rendering fails", func() {
+			// given
+			pathToBrokenTpl := filepath.Join("broken-line", path)
+			Expect(afero.WriteFile(inMemFS.Fs, pathToBrokenTpl, []byte(resourceYaml+"\n {{ required \"NotExistingKey must be defined\" .NotExistingKey }}"), 0644)).To(Succeed())
+			data := map[string]any{
+				"TargetNamespace": "template-ns",
+			}
+
+			// when
+			_, err := manifest.Create(inMemFS, pathToBrokenTpl).Process(data)
+
+			// then
+			Expect(err).Should(MatchError(ContainSubstring(pathToBrokenTpl + ":10:")))
+		})
+
+		It("should not escape values as HTML", func() {
+			// given
+			pathToTpl := filepath.Join("unescaped", path)
+			Expect(afero.WriteFile(inMemFS.Fs, pathToTpl, []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-configmap
+  namespace: {{ .TargetNamespace }}
+data:
+  audience: {{ .Audience }}
+`), 0644)).To(Succeed())
+			data := map[string]any{
+				"TargetNamespace": "template-ns",
+				"Audience":        "https://kubernetes.default.svc?a=b&c=d",
+			}
+
+			// when
+			objs := process(data, manifest.Create(inMemFS, pathToTpl))
+
+			// then
+			Expect(objs[0].Object["data"]).To(HaveKeyWithValue("audience", "https://kubernetes.default.svc?a=b&c=d"))
+		})
+
+	})
+
+	Describe("Template functions", func() {
+
+		var data map[string]any
+
+		BeforeEach(func() {
+			data = map[string]any{
+				"TargetNamespace": "template-ns",
+				"Empty":           "",
+				"Audiences":       []string{"aud-1", "aud-2"},
+				"Labels":          map[string]string{"app": "test"},
+				"dashed-key":      "dashed-value",
+			}
+		})
+
+		render := func(content string) (map[string]any, error) {
+			pathToTpl := "funcs/template.tmpl.yaml"
+			Expect(afero.WriteFile(inMemFS.Fs, pathToTpl, []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-configmap
+  namespace: {{ .TargetNamespace }}
+data:
+`+content), 0644)).To(Succeed())
+
+			objs, err := manifest.Create(inMemFS, pathToTpl).Process(data)
+			if err != nil {
+				return nil, err
+			}
+
+			Expect(objs).To(HaveLen(1))
+			cfgMapData, _ := objs[0].Object["data"].(map[string]any)
+
+			return cfgMapData, nil
+		}
+
+		It("should fall back to default value when value is empty", func() {
+			cfgMapData, err := render(`  value: {{ .Empty | default "fallback" }}`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfgMapData).To(HaveKeyWithValue("value", "fallback"))
+		})
+
+		It("should fall back to default value when defaulted key is missing", func() {
+			cfgMapData, err := render(`  value: {{ .Missing | default "fallback" }}`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfgMapData).To(HaveKeyWithValue("value", "fallback"))
+		})
+
+		It("should fall back to default value when optional key is missing", func() {
+			cfgMapData, err := render(`  value: {{ if hasKey . "Missing" }}{{ .Missing }}{{ else }}fallback{{ end }}`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfgMapData).To(HaveKeyWithValue("value", "fallback"))
+		})
+
+		It("should quote and base64 encode values", func() {
+			cfgMapData, err := render(`  quoted: {{ quote "yes" }}
+  encoded: {{ b64enc .TargetNamespace }}`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfgMapData).To(HaveKeyWithValue("quoted", "yes"))
+			Expect(cfgMapData).To(HaveKeyWithValue("encoded", "dGVtcGxhdGUtbnM="))
+		})
+
+		It("should render lists using toYaml and indent", func() {
+			cfgMapData, err := render(`  audiences: |
+{{ toYaml .Audiences | indent 4 }}`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfgMapData).To(HaveKeyWithValue("audiences", "- aud-1\n- aud-2"))
+		})
+
+		It("should check presence of keys and look up data", func() {
+			cfgMapData, err := render(`  hasApp: {{ hasKey .Labels "app" | quote }}
+  hasOther: {{ hasKey .Labels "other" | quote }}
+  dashed: {{ data "dashed-key" }}`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfgMapData).To(HaveKeyWithValue("hasApp", "true"))
+			Expect(cfgMapData).To(HaveKeyWithValue("hasOther", "false"))
+			Expect(cfgMapData).To(HaveKeyWithValue("dashed", "dashed-value"))
+		})
+
+		It("should fail rendering when required value is empty", func() {
+			_, err := render(`  value: {{ required "value must be defined" .Empty }}`)
+
+			Expect(err).To(MatchError(ContainSubstring("value must be defined")))
+		})
 	})
 
 })
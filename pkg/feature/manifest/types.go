@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 	"io"
 	"io/fs"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
@@ -21,10 +22,10 @@ import (
 func Create(fsys fs.FS, path string) *Manifest {
 	basePath := filepath.Base(path)
 	return &Manifest{
-		name:  basePath,
-		path:  path,
-		patch: isPatch(basePath),
-		fsys:  fsys,
+		name:      basePath,
+		path:      path,
+		patchType: patchTypeOf(basePath),
+		fsys:      fsys,
 	}
 }
 
@@ -59,8 +60,9 @@ func LoadManifests(fsys fs.FS, path string) ([]*Manifest, error) {
 type Manifest struct {
 	name,
 	path string
-	patch bool
-	fsys  fs.FS
+	// patchType defines how the manifest is applied to the existing resource. Empty if the manifest is not a patch.
+	patchType k8stypes.PatchType
+	fsys      fs.FS
 }
 
 // Applier wraps an instance of Manifest and provides a way to apply it to the cluster.
@@ -68,6 +70,10 @@ type Applier struct {
 	manifest *Manifest
 }
 
+var _ resource.Applier = (*Applier)(nil)
+var _ resource.Planner = (*Applier)(nil)
+var _ resource.Snapshotter = (*Applier)(nil)
+
 func createApplier(manifest *Manifest) *Applier {
 	return &Applier{
 		manifest: manifest,
@@ -82,17 +88,52 @@ func (a Applier) Apply(ctx context.Context, cli client.Client, data map[string]a
 	}
 
 	applierFunc := resource.Apply
-	if a.manifest.patch {
+	if a.manifest.isPatch() {
 		applierFunc = func(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured, _ ...cluster.MetaOptions) error {
-			return resource.Patch(ctx, cli, objects)
+			return resource.PatchWithStrategy(ctx, cli, a.manifest.patchType, objects)
 		}
 	}
 
 	return applierFunc(ctx, cli, objects, options...)
 }
 
+// Plan processes owned manifest and computes changes applying it would introduce to the cluster
+// using server-side dry-run. Nothing is persisted in the cluster.
+func (a Applier) Plan(ctx context.Context, cli client.Client, data map[string]any, options ...cluster.MetaOptions) ([]resource.Change, error) {
+	objects, errProcess := a.manifest.Process(data)
+	if errProcess != nil {
+		return nil, errProcess
+	}
+
+	if a.manifest.isPatch() {
+		return resource.PlanPatchWithStrategy(ctx, cli, a.manifest.patchType, objects)
+	}
+
+	return resource.PlanApply(ctx, cli, objects, options...)
+}
+
+// Snapshot captures the state of the fields changed by the patch manifest, so the patch can be reverted later.
+// Manifests which are not patches do not produce any snapshots, as created resources are garbage collected.
+func (a Applier) Snapshot(ctx context.Context, cli client.Client, data map[string]any) ([]resource.Snapshot, error) {
+	if !a.manifest.isPatch() {
+		return nil, nil
+	}
+
+	objects, errProcess := a.manifest.Process(data)
+	if errProcess != nil {
+		return nil, errProcess
+	}
+
+	return resource.TakeSnapshots(ctx, cli, a.manifest.patchType, objects)
+}
+
 // Process allows any arbitrary struct to be passed and used while processing the content of the manifest.
 func (m *Manifest) Process(data any) ([]*unstructured.Unstructured, error) {
+	return m.process(data)
+}
+
+// process renders the manifest using given template options, e.g. missingkey=error used by Validate.
+func (m *Manifest) process(data any, templateOptions ...string) ([]*unstructured.Unstructured, error) {
 	manifestFile, err := m.fsys.Open(m.path)
 	if err != nil {
 		return nil, err
@@ -108,8 +149,10 @@ func (m *Manifest) Process(data any) ([]*unstructured.Unstructured, error) {
 	resources := string(content)
 
 	if isTemplate(m.path) {
-		tmpl, err := template.New(m.name).
-			Option("missingkey=error").
+		// Template is named after the manifest path, so rendering errors point to the exact file and line.
+		tmpl, err := template.New(m.path).
+			Option(templateOptions...).
+			Funcs(templateFuncs(data)).
 			Parse(resources)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse template: %w", err)
@@ -126,8 +169,28 @@ func (m *Manifest) Process(data any) ([]*unstructured.Unstructured, error) {
 	return conversion.StrToUnstructured(resources)
 }
 
-func isPatch(path string) bool {
-	return strings.Contains(filepath.Base(path), ".patch.")
+func (m *Manifest) isPatch() bool {
+	return m.patchType != ""
+}
+
+// patchTypeOf determines the patch strategy based on the file naming convention:
+//   - ".patch." for JSON merge patch (RFC 7386),
+//   - ".jsonpatch." for JSON patch (RFC 6902), where operations are defined in the "patch" field of the patched object,
+//   - ".smpatch." for strategic merge patch (supported only for built-in types).
+//
+// Empty patch type is returned for manifests which are not patches.
+func patchTypeOf(path string) k8stypes.PatchType {
+	fileName := filepath.Base(path)
+	switch {
+	case strings.Contains(fileName, ".jsonpatch."):
+		return k8stypes.JSONPatchType
+	case strings.Contains(fileName, ".smpatch."):
+		return k8stypes.StrategicMergePatchType
+	case strings.Contains(fileName, ".patch."):
+		return k8stypes.MergePatchType
+	default:
+		return ""
+	}
 }
 
 func isTemplate(path string) bool {
@@ -0,0 +1,52 @@
+package manifest
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Validate renders all the manifests found under the given paths using provided data, the same way they are
+// processed when applied, and checks that each of the resulting objects has apiVersion, kind and name set.
+// It allows to catch broken templates without a cluster, which otherwise surface only when the feature is applied.
+func Validate(fsys fs.FS, data any, paths ...string) error {
+	var multiErr *multierror.Error
+
+	for _, path := range paths {
+		manifests, errLoad := LoadManifests(fsys, path)
+		if errLoad != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("failed loading manifests from %s: %w", path, errLoad))
+
+			continue
+		}
+
+		for _, m := range manifests {
+			multiErr = multierror.Append(multiErr, m.validate(data))
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
+func (m *Manifest) validate(data any) error {
+	// Missing keys fail validation, so typos are not rendered as "<no value>" or silently replaced by defaults.
+	objects, errProcess := m.process(data, "missingkey=error")
+	if errProcess != nil {
+		return fmt.Errorf("%s: %w", m.path, errProcess)
+	}
+
+	if len(objects) == 0 {
+		return fmt.Errorf("%s: no objects defined", m.path)
+	}
+
+	var multiErr *multierror.Error
+	for i, obj := range objects {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("%s: object #%d is missing apiVersion, kind or name (apiVersion=%q, kind=%q, name=%q)",
+				m.path, i, obj.GetAPIVersion(), obj.GetKind(), obj.GetName()))
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
@@ -0,0 +1,94 @@
+package manifest_test
+
+import (
+	"github.com/spf13/afero"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest Validation", func() {
+
+	var inMemFS AferoFsAdapter
+
+	data := map[string]any{"TargetNamespace": "validated-ns"}
+
+	BeforeEach(func() {
+		inMemFS = AferoFsAdapter{afero.NewMemMapFs()}
+	})
+
+	writeManifest := func(path, content string) {
+		Expect(afero.WriteFile(inMemFS.Fs, path, []byte(content), 0644)).To(Succeed())
+	}
+
+	It("should accept rendered templates defining complete objects", func() {
+		// given
+		writeManifest("manifests/cm.tmpl.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-configmap
+  namespace: {{ .TargetNamespace }}
+`)
+
+		// when
+		err := manifest.Validate(inMemFS, data, "manifests")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should report templates referring to missing data", func() {
+		// given
+		writeManifest("manifests/cm.tmpl.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-configmap
+  namespace: {{ .ControlPlane.Namespace }}
+`)
+
+		// when
+		err := manifest.Validate(inMemFS, data, "manifests")
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("manifests/cm.tmpl.yaml")))
+	})
+
+	It("should report templates referring to missing keys", func() {
+		// given
+		writeManifest("manifests/cm.tmpl.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-configmap
+  namespace: {{ .TargetNamespace }}
+data:
+  mode: {{ .Mode | default "default" }}
+`)
+
+		// when
+		err := manifest.Validate(inMemFS, data, "manifests")
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring(`map has no entry for key "Mode"`)))
+	})
+
+	It("should report objects without name", func() {
+		// given
+		writeManifest("manifests/secret.tmpl.yaml", `
+apiVersion: v1
+kind: Secret
+metadata:
+  namespace: {{ .TargetNamespace }}
+`)
+
+		// when
+		err := manifest.Validate(inMemFS, data, "manifests")
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("missing apiVersion, kind or name")))
+	})
+})
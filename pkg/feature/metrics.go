@@ -0,0 +1,123 @@
+package feature
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+)
+
+// Phases of the feature lifecycle reported by the metrics. Steps of applying the feature are labelled
+// with the corresponding featurev1.ConditionReason, so they match the reasons reported in FeatureTracker conditions.
+const (
+	phaseTotal   = "Total"
+	phaseCleanup = "Cleanup"
+)
+
+//nolint:gochecknoglobals // Reason: metrics are registered once in the controller-runtime registry
+var (
+	applyDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "odh_feature_apply_duration_seconds",
+			Help:    "Time spent applying features, by feature and phase of applying it.",
+			Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+		},
+		[]string{"feature", "phase"},
+	)
+
+	cleanupDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "odh_feature_cleanup_duration_seconds",
+			Help:    "Time spent cleaning up features.",
+			Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120},
+		},
+		[]string{"feature"},
+	)
+
+	failures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "odh_feature_failures_total",
+			Help: "Number of failed attempts to apply features, by the reason reported in FeatureTracker condition.",
+		},
+		[]string{"feature", "reason"},
+	)
+
+	trackerPhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_feature_tracker_phase",
+			Help: "Current phase of the FeatureTracker. The series of the current phase has value 1, the others 0.",
+		},
+		[]string{"feature", "tracker", "phase"},
+	)
+)
+
+//nolint:gochecknoglobals // Reason: fixed list of phases used to reset the gauge
+var trackerPhases = []string{status.PhaseProgressing, status.PhaseReady, status.PhaseError}
+
+func init() { //nolint:gochecknoinits // Reason: metrics have to be registered before the manager starts serving them
+	metrics.Registry.MustRegister(applyDuration, cleanupDuration, failures, trackerPhase)
+}
+
+// observeApply measures duration of the phase of applying the feature. The returned func should be called
+// when the phase is finished.
+func observeApply(f *Feature, phase string) func() {
+	start := time.Now()
+
+	return func() {
+		applyDuration.WithLabelValues(f.Name, phase).Observe(time.Since(start).Seconds())
+	}
+}
+
+func observeCleanup(f *Feature) func() {
+	start := time.Now()
+
+	return func() {
+		cleanupDuration.WithLabelValues(f.Name).Observe(time.Since(start).Seconds())
+	}
+}
+
+// recordFailure increments failures counter using the reason carried by the error.
+// Conditions of non-blocking features which are not met yet are not considered failures.
+func recordFailure(f *Feature, err error) {
+	if err == nil {
+		return
+	}
+
+	if _, notReady := IsNotReady(err); notReady {
+		return
+	}
+
+	failures.WithLabelValues(f.Name, string(reasonOf(err))).Inc()
+}
+
+// recordTrackerPhase sets the gauge of the current FeatureTracker phase, resetting the other phases.
+func recordTrackerPhase(f *Feature, tracker *featurev1.FeatureTracker, phase string) {
+	for _, knownPhase := range trackerPhases {
+		value := 0.0
+		if knownPhase == phase {
+			value = 1
+		}
+
+		trackerPhase.WithLabelValues(f.Name, tracker.Name, knownPhase).Set(value)
+	}
+}
+
+// forgetTracker removes the series of the FeatureTracker which no longer exists.
+func forgetTracker(f *Feature) {
+	trackerPhase.DeletePartialMatch(prometheus.Labels{"feature": f.Name})
+}
+
+// reasonOf determines the condition reason carried by the error.
+func reasonOf(err error) featurev1.FeatureConditionReason {
+	var conditionErr *withConditionReasonError
+	if errors.As(err, &conditionErr) {
+		return conditionErr.reason
+	}
+
+	// generic reason when error is not related to any specific step of the feature apply
+	return featurev1.ConditionReason.FailedApplying
+}
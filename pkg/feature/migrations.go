@@ -0,0 +1,126 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+)
+
+// migration holds actions bringing resources created by releases older than `from` to the state expected by `from` release.
+type migration struct {
+	from    semver.Version
+	actions []Action
+}
+
+// developmentVersion is the release the operator is built from, set by the Makefile using VERSION (see GO_LDFLAGS).
+var developmentVersion string
+
+// currentRelease is the version of the running operator. Development builds, which are reported as 0.0.0,
+// fall back to the developmentVersion, so migrations apply to them as well.
+func currentRelease() semver.Version {
+	current := cluster.GetRelease().Version.Version
+	if !current.Equals(semver.Version{}) || developmentVersion == "" {
+		return current
+	}
+
+	if version, err := semver.ParseTolerant(developmentVersion); err == nil {
+		return version
+	}
+
+	return current
+}
+
+// migratedVersion is the release for which migrations of the feature have been applied.
+// FeatureTrackers created before migrations were introduced do not have it recorded, so all the migrations apply to them.
+func migratedVersion(tracker *featurev1.FeatureTracker) (semver.Version, error) {
+	if tracker.Status.MigratedVersion == "" {
+		return semver.Version{}, nil
+	}
+
+	version, err := semver.Parse(tracker.Status.MigratedVersion)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid migrated version of FeatureTracker %s: %w", tracker.Name, err)
+	}
+
+	return version, nil
+}
+
+// pendingMigrations returns migrations introduced after the `migrated` release and not later than `current` one, ordered by their version.
+func pendingMigrations(migrations []migration, migrated, current semver.Version) []migration {
+	var pending []migration
+	for _, m := range migrations {
+		if migrated.LT(m.from) && m.from.LTE(current) {
+			pending = append(pending, m)
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].from.LT(pending[j].from)
+	})
+
+	return pending
+}
+
+// migrate runs migrations which have not been applied yet for the release of the operator and records the release in the FeatureTracker,
+// so they are applied only once. When any of the migrations fails, the release is not recorded and migrations are retried
+// on the next reconciliation.
+func (f *Feature) migrate(ctx context.Context, cli client.Client) error {
+	migrated, err := migratedVersion(f.tracker)
+	if err != nil {
+		return err
+	}
+
+	current := currentRelease()
+	if !migrated.LT(current) {
+		return nil
+	}
+
+	for _, m := range pendingMigrations(f.migrations, migrated, current) {
+		f.Log.Info("applying migration", "from", m.from.String(), "migrated", migrated.String())
+		for _, action := range m.actions {
+			if errMigrate := action(ctx, cli, f); errMigrate != nil {
+				return fmt.Errorf("failed applying migration of feature '%s' for release %s: %w", f.Name, m.from, errMigrate)
+			}
+		}
+	}
+
+	updated, errUpdate := status.UpdateWithRetry(ctx, cli, f.tracker, func(saved *featurev1.FeatureTracker) {
+		saved.Status.MigratedVersion = current.String()
+	})
+	if errUpdate != nil {
+		return fmt.Errorf("failed recording migrated version of FeatureTracker %s: %w", f.tracker.Name, errUpdate)
+	}
+
+	f.tracker.Status.MigratedVersion = updated.Status.MigratedVersion
+
+	return nil
+}
+
+// ApplyManifests creates an Action applying given manifests, e.g. patches, using the feature data.
+// It can be used to define migrations of the feature, see featureBuilder.Migrations.
+func ApplyManifests(creators ...resource.Creator) Action {
+	return func(ctx context.Context, cli client.Client, f *Feature) error {
+		for _, creator := range creators {
+			appliers, errCreate := creator.Create()
+			if errCreate != nil {
+				return errCreate
+			}
+
+			for i := range appliers {
+				if errApply := appliers[i].Apply(ctx, cli, f.data, DefaultMetaOptions(f)...); errApply != nil {
+					return errApply
+				}
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package feature_test
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Current release", func() {
+
+	It("should fall back to the development version when the release is not known", func() {
+		// given
+		DeferCleanup(feature.SetDevelopmentVersion("2.22.0"))
+
+		// then
+		Expect(feature.CurrentRelease()).To(Equal(semver.MustParse("2.22.0")))
+	})
+
+	It("should report unknown release when the development version is not set", func() {
+		// given
+		DeferCleanup(feature.SetDevelopmentVersion(""))
+
+		// then
+		Expect(feature.CurrentRelease()).To(Equal(semver.Version{}))
+	})
+})
@@ -0,0 +1,180 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+)
+
+// FeaturePlan describes changes which applying a Feature would introduce to the cluster.
+type FeaturePlan struct {
+	Feature string            `json:"feature"`
+	Enabled bool              `json:"enabled"`
+	Changes []resource.Change `json:"changes,omitempty"`
+}
+
+// Plan runs the feature in "plan" mode. It loads the data, checks preconditions and renders all the manifests,
+// but instead of applying them it computes the changes they would introduce to the cluster using server-side dry-run.
+//
+// All the interactions with the cluster are performed using dry-run client, so nothing is persisted,
+// including the FeatureTracker. Objects created by preconditions, such as namespaces created by
+// CreateNamespaceIfNotExists, are reported as planned creations. Resources created programmatically
+// (see WithResources) and postconditions are not part of the plan, as their effects cannot be determined
+// without invoking them.
+func (f *Feature) Plan(ctx context.Context, cli client.Client) (*FeaturePlan, error) {
+	dryRunCli := &planningClient{Client: client.NewDryRunClient(cli), namespaces: sets.New[string]()}
+
+	plan := &FeaturePlan{Feature: f.Name}
+
+	enabled, errEnabled := f.Enabled(ctx, dryRunCli, f)
+	if errEnabled != nil {
+		return nil, errEnabled
+	}
+
+	if !enabled {
+		return plan, nil
+	}
+
+	plan.Enabled = true
+
+	if _, trackerErr := getOrCreateFeatureTracker(ctx, dryRunCli, f); trackerErr != nil {
+		return nil, trackerErr
+	}
+
+	if errDataLoad := f.loadData(ctx, dryRunCli); errDataLoad != nil {
+		return nil, &withConditionReasonError{reason: featurev1.ConditionReason.LoadTemplateData, err: errDataLoad}
+	}
+
+	var multiErr *multierror.Error
+	dryRunCli.recording = true
+	for _, precondition := range f.preconditions {
+		multiErr = multierror.Append(multiErr, precondition(ctx, dryRunCli, f))
+	}
+	dryRunCli.recording = false
+	if preconditionsErr := multiErr.ErrorOrNil(); preconditionsErr != nil {
+		return nil, &withConditionReasonError{reason: featurev1.ConditionReason.PreConditions, err: preconditionsErr}
+	}
+
+	plan.Changes = append(plan.Changes, dryRunCli.created...)
+
+	for i := range f.appliers {
+		planner, supportsPlan := f.appliers[i].(resource.Planner)
+		if !supportsPlan {
+			return nil, fmt.Errorf("manifests of feature '%s' cannot be planned: %T does not support dry-run", f.Name, f.appliers[i])
+		}
+
+		changes, errPlan := planner.Plan(ctx, dryRunCli, f.data, DefaultMetaOptions(f)...)
+		if errPlan != nil {
+			return nil, &withConditionReasonError{reason: featurev1.ConditionReason.ApplyManifests, err: errPlan}
+		}
+
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	return plan, nil
+}
+
+// planningClient records objects created while it is recording, so side effects of preconditions are reported
+// in the plan. As the dry-run client does not persist namespaces, objects created in namespaces planned
+// to be created would be rejected by the API server. Their creation is assumed to succeed instead.
+type planningClient struct {
+	client.Client
+
+	recording  bool
+	created    []resource.Change
+	namespaces sets.Set[string]
+}
+
+func (c *planningClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.inPlannedNamespace(obj) {
+		return nil
+	}
+
+	if err := c.Client.Create(ctx, obj, opts...); err != nil || !c.recording {
+		return err
+	}
+
+	objectGVK, errGVK := apiutil.GVKForObject(obj, c.Scheme())
+	if errGVK != nil {
+		return errGVK
+	}
+
+	content, errConvert := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if errConvert != nil {
+		return fmt.Errorf("failed converting %s %s: %w", objectGVK.Kind, obj.GetName(), errConvert)
+	}
+	created := &unstructured.Unstructured{Object: content}
+	created.SetGroupVersionKind(objectGVK)
+
+	change, errChange := resource.NewChange(created, nil, created)
+	if errChange != nil {
+		return errChange
+	}
+	c.created = append(c.created, change)
+
+	if objectGVK.GroupKind() == corev1.SchemeGroupVersion.WithKind("Namespace").GroupKind() {
+		c.namespaces.Insert(obj.GetName())
+	}
+
+	return nil
+}
+
+// inPlannedNamespace checks if the object is a namespace planned to be created or if it belongs to one.
+func (c *planningClient) inPlannedNamespace(obj client.Object) bool {
+	if obj.GetNamespace() != "" {
+		return c.namespaces.Has(obj.GetNamespace())
+	}
+
+	_, isNamespace := obj.(*corev1.Namespace)
+	isNamespace = isNamespace || obj.GetObjectKind().GroupVersionKind().Kind == "Namespace"
+
+	return isNamespace && c.namespaces.Has(obj.GetName())
+}
+
+// Plan computes changes which applying all the features of the handler would introduce to the cluster.
+// Features are planned in the order of their dependencies. See Feature.Plan for details.
+func (fh *FeaturesHandler) Plan(ctx context.Context, cli client.Client) ([]*FeaturePlan, error) {
+	fh.features = make([]*Feature, 0)
+
+	for _, featuresProvider := range fh.featuresProviders {
+		if err := featuresProvider(fh); err != nil {
+			return nil, fmt.Errorf("failed adding features to the handler. cause: %w", err)
+		}
+	}
+
+	graph, graphErr := newFeatureGraph(fh.features)
+	if graphErr != nil {
+		return nil, fmt.Errorf("failed resolving dependencies between features. cause: %w", graphErr)
+	}
+
+	var multiErr *multierror.Error
+	plans := make([]*FeaturePlan, 0, len(fh.features))
+	for _, f := range graph.topologicalOrder() {
+		plan, planErr := f.Plan(ctx, cli)
+		if planErr != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("failed planning feature '%s'. cause: %w", f.Name, planErr))
+
+			continue
+		}
+
+		plans = append(plans, plan)
+	}
+
+	return plans, multiErr.ErrorOrNil()
+}
+
+// Plan computes changes which applying features of the wrapped handler would introduce to the cluster.
+// As nothing is applied, the status is not reported.
+func (h HandlerWithReporter[T]) Plan(ctx context.Context, cli client.Client) ([]*FeaturePlan, error) {
+	return h.handler.Plan(ctx, cli)
+}
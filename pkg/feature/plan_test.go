@@ -0,0 +1,122 @@
+package feature_test
+
+import (
+	"context"
+	"testing/fstest"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Planning features", func() {
+
+	It("should plan feature which has no FeatureTracker in the cluster yet", func(ctx context.Context) {
+		// given
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(featurev1.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+
+		dsci := &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci", UID: "dsci-uid"},
+			Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+		}
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dsci).
+			WithStatusSubresource(&featurev1.FeatureTracker{}).
+			Build()
+
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("fresh-feature"))
+		})
+
+		// when
+		plans, err := featuresHandler.Plan(ctx, cli)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].Enabled).To(BeTrue())
+
+		errTracker := cli.Get(ctx, client.ObjectKey{Name: "test-ns-fresh-feature"}, &featurev1.FeatureTracker{})
+		Expect(k8serr.IsNotFound(errTracker)).To(BeTrue())
+	})
+
+	It("should report namespace created by precondition as planned creation", func(ctx context.Context) {
+		// given
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(featurev1.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+
+		dsci := &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci", UID: "dsci-uid"},
+			Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+		}
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dsci).
+			WithStatusSubresource(&featurev1.FeatureTracker{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// the API server rejects objects created in namespaces which do not exist
+				Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if obj.GetNamespace() != "" {
+						if err := cli.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, &corev1.Namespace{}); err != nil {
+							return err
+						}
+					}
+
+					return cli.Create(ctx, obj, opts...)
+				},
+			}).
+			Build()
+
+		manifests := fstest.MapFS{
+			"manifests/cm.tmpl.yaml": &fstest.MapFile{Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: planned-cm
+  namespace: planned-ns
+`)},
+		}
+
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("namespaced-feature").
+				Manifests(manifest.Location(manifests).Include("manifests")).
+				PreConditions(feature.CreateNamespaceIfNotExists("planned-ns")),
+			)
+		})
+
+		// when
+		plans, err := featuresHandler.Plan(ctx, cli)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].Changes).To(HaveLen(2))
+		Expect(plans[0].Changes[0].Action).To(Equal(resource.ActionCreate))
+		Expect(plans[0].Changes[0].GroupVersionKind.Kind).To(Equal("Namespace"))
+		Expect(plans[0].Changes[0].Name).To(Equal("planned-ns"))
+		Expect(plans[0].Changes[1].Action).To(Equal(resource.ActionCreate))
+		Expect(plans[0].Changes[1].Name).To(Equal("planned-cm"))
+
+		errNamespace := cli.Get(ctx, client.ObjectKey{Name: "planned-ns"}, &corev1.Namespace{})
+		Expect(k8serr.IsNotFound(errNamespace)).To(BeTrue())
+	})
+})
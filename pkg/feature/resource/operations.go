@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
@@ -13,6 +14,9 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 )
 
+// jsonPatchField is the field of the JSON patch manifest which holds the list of RFC 6902 operations.
+const jsonPatchField = "patch"
+
 func Apply(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured, metaOptions ...cluster.MetaOptions) error {
 	for _, source := range objects {
 		for _, opt := range metaOptions {
@@ -33,7 +37,7 @@ func Apply(ctx context.Context, cli client.Client, objects []*unstructured.Unstr
 
 		justCreated := false
 		if k8serr.IsNotFound(errGet) {
-			if errCreate := cli.Create(ctx, target); client.IgnoreAlreadyExists(errCreate) != nil {
+			if errCreate := cli.Create(ctx, target, client.FieldOwner(cluster.FieldManager)); client.IgnoreAlreadyExists(errCreate) != nil {
 				return fmt.Errorf("failed to create source %s/%s: %w", namespace, name, errCreate)
 			}
 
@@ -50,41 +54,76 @@ func Apply(ctx context.Context, cli client.Client, objects []*unstructured.Unstr
 	return nil
 }
 
+// Patch merges the given patches into existing resources using JSON merge patch.
 func Patch(ctx context.Context, cli client.Client, patches []*unstructured.Unstructured) error {
+	return PatchWithStrategy(ctx, cli, k8stypes.MergePatchType, patches)
+}
+
+// PatchWithStrategy patches existing resources using the given patch type. Supported types are:
+//   - JSON merge patch (RFC 7386), where the patch is the partial object to be merged,
+//   - strategic merge patch, where the patch is the partial object to be merged (only for built-in types),
+//   - JSON patch (RFC 6902), where the object identifies the patched resource and its "patch" field holds the operations.
+func PatchWithStrategy(ctx context.Context, cli client.Client, patchType k8stypes.PatchType, patches []*unstructured.Unstructured) error {
 	for _, patch := range patches {
-		if errPatch := patchUsingMergeStrategy(ctx, cli, patch); errPatch != nil {
-			return errPatch
+		data, errData := PatchData(patchType, patch)
+		if errData != nil {
+			return errData
+		}
+
+		if errPatch := cli.Patch(ctx, TargetOf(patch), client.RawPatch(patchType, data)); errPatch != nil {
+			return fmt.Errorf("failed patching resource %s/%s: %w", patch.GetNamespace(), patch.GetName(), errPatch)
 		}
 	}
 
 	return nil
 }
 
-// patchUsingApplyStrategy applies a server-side apply patch to a Kubernetes resource.
-// It treats the provided source as the desired state of the resource and attempts to
-// reconcile the target resource to match this state. The function takes ownership of the
-// fields specified in the target and will ensure they match desired state.
-func patchUsingApplyStrategy(ctx context.Context, cli client.Client, source, target *unstructured.Unstructured) error {
-	data, errJSON := source.MarshalJSON()
-	if errJSON != nil {
-		return fmt.Errorf("error converting yaml to json: %w", errJSON)
+// PatchData returns the body of the patch request for the given patch type.
+func PatchData(patchType k8stypes.PatchType, patch *unstructured.Unstructured) ([]byte, error) {
+	switch patchType { //nolint:exhaustive // Reason: server-side apply is handled by Apply
+	case k8stypes.MergePatchType, k8stypes.StrategicMergePatchType:
+		data, errJSON := patch.MarshalJSON()
+		if errJSON != nil {
+			return nil, fmt.Errorf("error converting yaml to json: %w", errJSON)
+		}
+
+		return data, nil
+	case k8stypes.JSONPatchType:
+		operations, found, errOps := unstructured.NestedSlice(patch.Object, jsonPatchField)
+		if errOps != nil {
+			return nil, fmt.Errorf("invalid JSON patch for %s/%s: %w", patch.GetNamespace(), patch.GetName(), errOps)
+		}
+		if !found {
+			return nil, fmt.Errorf("JSON patch for %s/%s does not define any operations in %q field", patch.GetNamespace(), patch.GetName(), jsonPatchField)
+		}
+
+		data, errJSON := json.Marshal(operations)
+		if errJSON != nil {
+			return nil, fmt.Errorf("error converting JSON patch operations to json: %w", errJSON)
+		}
+
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsupported patch type %s", patchType)
 	}
-	return cli.Patch(ctx, target, client.RawPatch(k8stypes.ApplyPatchType, data), client.ForceOwnership, client.FieldOwner("rhods-operator"))
 }
 
-// patchUsingMergeStrategy merges the specified fields into the existing resources.
-// Fields included in the patch will overwrite existing fields, while fields not included will remain unchanged.
-func patchUsingMergeStrategy(ctx context.Context, cli client.Client, patch *unstructured.Unstructured) error {
-	data, errJSON := patch.MarshalJSON()
-	if errJSON != nil {
-		return fmt.Errorf("error converting yaml to json: %w", errJSON)
-	}
+// TargetOf returns an object identifying the resource to which the patch is applied.
+func TargetOf(patch *unstructured.Unstructured) *unstructured.Unstructured {
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(patch.GroupVersionKind())
+	target.SetNamespace(patch.GetNamespace())
+	target.SetName(patch.GetName())
 
-	if errPatch := cli.Patch(ctx, patch, client.RawPatch(k8stypes.MergePatchType, data)); errPatch != nil {
-		return fmt.Errorf("failed patching resource: %w", errPatch)
-	}
+	return target
+}
 
-	return nil
+// patchUsingApplyStrategy applies a server-side apply patch to a Kubernetes resource.
+// It treats the provided source as the desired state of the resource and attempts to
+// reconcile the target resource to match this state. Conflicts with other field managers
+// are resolved according to the policy of the target resource (see cluster.Apply).
+func patchUsingApplyStrategy(ctx context.Context, cli client.Client, source, target *unstructured.Unstructured) error {
+	return cluster.Apply(ctx, cli, source, target)
 }
 
 // At this point we only look at what is defined for the resource in the desired state (source),
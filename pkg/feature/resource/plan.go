@@ -0,0 +1,200 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+// Action describes what would happen to the object in the cluster when the resource is applied.
+type Action string
+
+const (
+	// ActionCreate means that the object does not exist in the cluster and would be created.
+	ActionCreate Action = "Create"
+	// ActionPatch means that the object exists in the cluster and would be changed.
+	ActionPatch Action = "Patch"
+	// ActionNoOp means that applying the resource would not change the object in the cluster.
+	ActionNoOp Action = "NoOp"
+	// ActionDelete means that the object exists in the cluster and would be deleted.
+	ActionDelete Action = "Delete"
+)
+
+// Change describes the outcome of applying a single object to the cluster, computed using server-side dry-run.
+type Change struct {
+	Action           Action                  `json:"action"`
+	GroupVersionKind schema.GroupVersionKind `json:"groupVersionKind"`
+	Namespace        string                  `json:"namespace,omitempty"`
+	Name             string                  `json:"name"`
+	// Diff is a JSON merge patch (RFC 7386) which transforms the current state of the object into
+	// the one after applying the resource. For objects which would be created it contains the whole object.
+	Diff string `json:"diff,omitempty"`
+}
+
+// PlanApply computes changes which Apply would introduce to the cluster for the given objects without persisting them.
+func PlanApply(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured, metaOptions ...cluster.MetaOptions) ([]Change, error) {
+	changes := make([]Change, 0, len(objects))
+
+	for _, source := range objects {
+		for _, opt := range metaOptions {
+			if err := opt(source); err != nil {
+				return nil, err
+			}
+		}
+
+		name := source.GetName()
+		namespace := source.GetNamespace()
+
+		current := source.DeepCopy()
+		errGet := cli.Get(ctx, k8stypes.NamespacedName{Name: name, Namespace: namespace}, current)
+		if client.IgnoreNotFound(errGet) != nil {
+			return nil, fmt.Errorf("failed to get resource %s/%s: %w", namespace, name, errGet)
+		}
+
+		if k8serr.IsNotFound(errGet) {
+			created := source.DeepCopy()
+			if errCreate := cli.Create(ctx, created, client.DryRunAll); errCreate != nil {
+				return nil, fmt.Errorf("failed to dry-run create of resource %s/%s: %w", namespace, name, errCreate)
+			}
+
+			change, errChange := NewChange(source, nil, created)
+			if errChange != nil {
+				return nil, errChange
+			}
+			changes = append(changes, change)
+
+			continue
+		}
+
+		if !shouldReconcile(source) {
+			changes = append(changes, Change{
+				Action:           ActionNoOp,
+				GroupVersionKind: source.GroupVersionKind(),
+				Namespace:        namespace,
+				Name:             name,
+			})
+
+			continue
+		}
+
+		data, errJSON := source.MarshalJSON()
+		if errJSON != nil {
+			return nil, fmt.Errorf("error converting yaml to json: %w", errJSON)
+		}
+
+		patched := current.DeepCopy()
+		if errPatch := cli.Patch(ctx, patched, client.RawPatch(k8stypes.ApplyPatchType, data),
+			client.DryRunAll, client.ForceOwnership, client.FieldOwner(cluster.FieldManager)); errPatch != nil {
+			return nil, fmt.Errorf("failed to dry-run reconcile of resource %s/%s: %w", namespace, name, errPatch)
+		}
+
+		change, errChange := NewChange(source, current, patched)
+		if errChange != nil {
+			return nil, errChange
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// PlanPatch computes changes which Patch would introduce to the cluster for the given patches without persisting them.
+func PlanPatch(ctx context.Context, cli client.Client, patches []*unstructured.Unstructured) ([]Change, error) {
+	return PlanPatchWithStrategy(ctx, cli, k8stypes.MergePatchType, patches)
+}
+
+// PlanPatchWithStrategy computes changes which PatchWithStrategy would introduce to the cluster for the given patches
+// without persisting them.
+func PlanPatchWithStrategy(ctx context.Context, cli client.Client, patchType k8stypes.PatchType, patches []*unstructured.Unstructured) ([]Change, error) {
+	changes := make([]Change, 0, len(patches))
+
+	for _, patch := range patches {
+		current := TargetOf(patch)
+		if errGet := cli.Get(ctx, client.ObjectKeyFromObject(patch), current); errGet != nil {
+			return nil, fmt.Errorf("failed to get resource %s/%s: %w", patch.GetNamespace(), patch.GetName(), errGet)
+		}
+
+		data, errData := PatchData(patchType, patch)
+		if errData != nil {
+			return nil, errData
+		}
+
+		patched := current.DeepCopy()
+		if errPatch := cli.Patch(ctx, patched, client.RawPatch(patchType, data), client.DryRunAll); errPatch != nil {
+			return nil, fmt.Errorf("failed to dry-run patch of resource %s/%s: %w", patch.GetNamespace(), patch.GetName(), errPatch)
+		}
+
+		change, errChange := NewChange(patch, current, patched)
+		if errChange != nil {
+			return nil, errChange
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// NewChange compares the current state of the object with the one returned by a dry-run request.
+// Nil current object means that the object does not exist in the cluster yet.
+func NewChange(source, current, desired *unstructured.Unstructured) (Change, error) {
+	change := Change{
+		Action:           ActionCreate,
+		GroupVersionKind: source.GroupVersionKind(),
+		Namespace:        source.GetNamespace(),
+		Name:             source.GetName(),
+	}
+
+	desiredJSON, errDesired := json.Marshal(withoutServerFields(desired).Object)
+	if errDesired != nil {
+		return change, fmt.Errorf("failed to serialize dry-run result of %s/%s: %w", change.Namespace, change.Name, errDesired)
+	}
+
+	if current == nil {
+		change.Diff = string(desiredJSON)
+
+		return change, nil
+	}
+
+	currentJSON, errCurrent := json.Marshal(withoutServerFields(current).Object)
+	if errCurrent != nil {
+		return change, fmt.Errorf("failed to serialize resource %s/%s: %w", change.Namespace, change.Name, errCurrent)
+	}
+
+	diff, errDiff := jsonpatch.CreateMergePatch(currentJSON, desiredJSON)
+	if errDiff != nil {
+		return change, fmt.Errorf("failed to compute diff for %s/%s: %w", change.Namespace, change.Name, errDiff)
+	}
+
+	change.Action = ActionPatch
+	if string(diff) == "{}" {
+		change.Action = ActionNoOp
+
+		return change, nil
+	}
+
+	change.Diff = string(diff)
+
+	return change, nil
+}
+
+// withoutServerFields strips metadata maintained by the API server, as it changes on every write
+// and does not describe the actual change of the object.
+func withoutServerFields(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	stripped := obj.DeepCopy()
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "generation")
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "uid")
+
+	return stripped
+}
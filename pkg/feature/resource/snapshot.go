@@ -0,0 +1,337 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+)
+
+// Snapshot captures the state of the fields of an existing object which are about to be patched.
+type Snapshot struct {
+	// Target identifies the patched object.
+	Target *unstructured.Unstructured
+	// Revert is a JSON merge patch which restores patched fields to their state at the time of the snapshot.
+	Revert map[string]any
+	// AddedEntries identify entries which the patch adds to the lists of the object, see RevertPatchFor.
+	AddedEntries []featurev1.ListEntries
+}
+
+// entryKey is the field identifying entries of the lists, whose added entries are removed on revert.
+const entryKey = "name"
+
+// Snapshotter is an interface that allows to capture the state of the resources before they are patched,
+// so that the patches can be reverted later.
+type Snapshotter interface {
+	Snapshot(ctx context.Context, cli client.Client, data map[string]any) ([]Snapshot, error)
+}
+
+// TakeSnapshots captures the current state of the fields which are changed by the given patches.
+func TakeSnapshots(ctx context.Context, cli client.Client, patchType k8stypes.PatchType, patches []*unstructured.Unstructured) ([]Snapshot, error) {
+	snapshots := make([]Snapshot, 0, len(patches))
+
+	for _, patch := range patches {
+		current := TargetOf(patch)
+		if errGet := cli.Get(ctx, client.ObjectKeyFromObject(patch), current); errGet != nil {
+			return nil, fmt.Errorf("failed to get resource %s/%s: %w", patch.GetNamespace(), patch.GetName(), errGet)
+		}
+
+		revert, addedEntries, errRevert := RevertPatchFor(current, patchType, patch)
+		if errRevert != nil {
+			return nil, errRevert
+		}
+
+		// nothing to revert when the patch has been applied before
+		if len(revert) == 0 && len(addedEntries) == 0 {
+			continue
+		}
+
+		snapshots = append(snapshots, Snapshot{Target: TargetOf(patch), Revert: revert, AddedEntries: addedEntries})
+	}
+
+	return snapshots, nil
+}
+
+// RevertPatchFor computes a JSON merge patch which restores the fields of the current object changed by the given patch.
+//
+// Fields which are not present in the current object are reverted by removing them. Fields which already hold
+// the patched values are not reverted, as their original state is unknown, e.g. when the patch has been applied
+// by an operator version which did not record snapshots.
+//
+// Lists of entries identified by name are not restored as a whole, so entries added by others are kept. Instead,
+// the entries which the patch adds to them are returned, so only these are removed on revert, see ListEntries.
+// As JSON merge patch replaces lists as a whole, other lists are restored entirely when any of their elements is changed by the patch.
+func RevertPatchFor(current *unstructured.Unstructured, patchType k8stypes.PatchType, patch *unstructured.Unstructured) (map[string]any, []featurev1.ListEntries, error) {
+	var changedFields [][]string
+	var addedEntries []featurev1.ListEntries
+
+	switch patchType { //nolint:exhaustive // Reason: server-side apply is handled by Apply
+	case k8stypes.MergePatchType, k8stypes.StrategicMergePatchType:
+		patchFields := withoutIdentity(patch.Object)
+		for _, field := range leafFields(patchFields, nil) {
+			patched, _, _ := unstructured.NestedFieldNoCopy(patchFields, field...)
+			if names, keyed := entryNames(patched); keyed {
+				addedEntries = appendAddedEntries(addedEntries, current.Object, field, names)
+
+				continue
+			}
+
+			if holdsValue(current.Object, field, patched) {
+				continue
+			}
+
+			changedFields = append(changedFields, field)
+		}
+	case k8stypes.JSONPatchType:
+		operations, _, errOps := unstructured.NestedSlice(patch.Object, jsonPatchField)
+		if errOps != nil {
+			return nil, nil, fmt.Errorf("invalid JSON patch for %s/%s: %w", patch.GetNamespace(), patch.GetName(), errOps)
+		}
+
+		for _, op := range operations {
+			operation, isMap := op.(map[string]any)
+			if !isMap {
+				return nil, nil, fmt.Errorf("invalid JSON patch operation for %s/%s: %v", patch.GetNamespace(), patch.GetName(), op)
+			}
+
+			if listField, name, isAdded := addedEntryOf(current.Object, operation); isAdded {
+				addedEntries = appendAddedEntries(addedEntries, current.Object, listField, []string{name})
+
+				continue
+			}
+
+			for _, pointerField := range []string{"path", "from"} {
+				if pointer, isString := operation[pointerField].(string); isString {
+					changedFields = append(changedFields, fieldOfPointer(current.Object, pointer))
+				}
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported patch type %s", patchType)
+	}
+
+	revert := map[string]any{}
+	for _, field := range changedFields {
+		if len(field) == 0 {
+			continue
+		}
+
+		if errSet := setOriginalValue(revert, current.Object, field); errSet != nil {
+			return nil, nil, fmt.Errorf("failed to snapshot %s of %s/%s: %w", strings.Join(field, "."), patch.GetNamespace(), patch.GetName(), errSet)
+		}
+	}
+
+	return revert, addedEntries, nil
+}
+
+// RemoveEntries removes the entries from the lists of the object. It reports whether any of them has been removed.
+func RemoveEntries(obj *unstructured.Unstructured, entries []featurev1.ListEntries) (bool, error) {
+	removed := false
+	for _, listEntries := range entries {
+		list, found, errGet := unstructured.NestedSlice(obj.Object, listEntries.Field...)
+		if errGet != nil {
+			return false, errGet
+		}
+		if !found {
+			continue
+		}
+
+		kept := make([]any, 0, len(list))
+		for _, entry := range list {
+			if entryMap, isMap := entry.(map[string]any); isMap && slices.Contains(listEntries.Values, fmt.Sprint(entryMap[listEntries.Key])) {
+				removed = true
+
+				continue
+			}
+
+			kept = append(kept, entry)
+		}
+
+		if errSet := unstructured.SetNestedSlice(obj.Object, kept, listEntries.Field...); errSet != nil {
+			return false, errSet
+		}
+	}
+
+	return removed, nil
+}
+
+// entryNames returns names of the entries when the value is a list of entries identified by their name.
+func entryNames(value any) ([]string, bool) {
+	list, isList := value.([]any)
+	if !isList || len(list) == 0 {
+		return nil, false
+	}
+
+	names := make([]string, 0, len(list))
+	for _, entry := range list {
+		entryMap, isMap := entry.(map[string]any)
+		if !isMap {
+			return nil, false
+		}
+
+		name, isString := entryMap[entryKey].(string)
+		if !isString {
+			return nil, false
+		}
+
+		names = append(names, name)
+	}
+
+	return names, true
+}
+
+// addedEntryOf returns the list and the name of the entry added to it by JSON patch "add" operation.
+func addedEntryOf(obj map[string]any, operation map[string]any) ([]string, string, bool) {
+	pointer, isString := operation["path"].(string)
+	if operation["op"] != "add" || !isString {
+		return nil, "", false
+	}
+
+	names, keyed := entryNames([]any{operation["value"]})
+	if !keyed {
+		return nil, "", false
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tokens[i], "~1", "/"), "~0", "~")
+	}
+
+	listField := tokens[:len(tokens)-1]
+	if _, found, _ := unstructured.NestedSlice(obj, listField...); !found {
+		return nil, "", false
+	}
+
+	return listField, names[0], true
+}
+
+// appendAddedEntries adds the names which are not present in the list of the object yet to the added entries.
+func appendAddedEntries(addedEntries []featurev1.ListEntries, obj map[string]any, field, names []string) []featurev1.ListEntries {
+	list, _, _ := unstructured.NestedSlice(obj, field...)
+	existing, _ := entryNames(list)
+
+	var added []string
+	for _, name := range names {
+		if !slices.Contains(existing, name) && !slices.Contains(added, name) {
+			added = append(added, name)
+		}
+	}
+
+	if len(added) == 0 {
+		return addedEntries
+	}
+
+	return append(addedEntries, featurev1.ListEntries{Field: slices.Clone(field), Key: entryKey, Values: added})
+}
+
+// holdsValue checks if the field of the object already holds the value set by the merge patch.
+func holdsValue(obj map[string]any, field []string, patched any) bool {
+	value, found, _ := unstructured.NestedFieldNoCopy(obj, field...)
+	if patched == nil {
+		// null removes the field in merge patch
+		return !found
+	}
+
+	return found && reflect.DeepEqual(value, patched)
+}
+
+// MergeMissing adds fields of the addition which are not yet defined in the revert patch.
+// Fields which are already defined are kept intact, so the earliest captured state is preserved.
+func MergeMissing(revert, addition map[string]any) {
+	for key, value := range addition {
+		existing, defined := revert[key]
+		if !defined {
+			revert[key] = value
+
+			continue
+		}
+
+		existingMap, existingIsMap := existing.(map[string]any)
+		valueMap, valueIsMap := value.(map[string]any)
+		if existingIsMap && valueIsMap {
+			MergeMissing(existingMap, valueMap)
+		}
+	}
+}
+
+// setOriginalValue stores the value of the field from the original object in the revert patch.
+// When the field (or any of its parents) does not exist, the revert patch removes the top-most missing one.
+func setOriginalValue(revert, original map[string]any, field []string) error {
+	for i := range field {
+		value, found, errGet := unstructured.NestedFieldCopy(original, field[:i+1]...)
+		if errGet != nil {
+			return errGet
+		}
+
+		if !found {
+			return unstructured.SetNestedField(revert, nil, field[:i+1]...)
+		}
+
+		if i == len(field)-1 {
+			return unstructured.SetNestedField(revert, value, field...)
+		}
+	}
+
+	return nil
+}
+
+// leafFields lists paths to all the fields which are not maps, or are empty maps.
+func leafFields(obj map[string]any, parent []string) [][]string {
+	var fields [][]string
+	for key, value := range obj {
+		field := append(append([]string{}, parent...), key)
+		if nested, isMap := value.(map[string]any); isMap && len(nested) > 0 {
+			fields = append(fields, leafFields(nested, field)...)
+
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// fieldOfPointer converts JSON pointer (RFC 6901) to the path of the field. As list elements cannot be
+// addressed by JSON merge patch, the path ends at the list when the pointer refers to its element.
+func fieldOfPointer(obj map[string]any, pointer string) []string {
+	var field []string
+	var current any = obj
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		// Lists (or scalars) are restored as a whole. Missing fields are traversed, so they can be removed.
+		currentMap, isMap := current.(map[string]any)
+		if !isMap && current != nil {
+			return field
+		}
+
+		field = append(field, token)
+		current = currentMap[token]
+	}
+
+	return field
+}
+
+// withoutIdentity removes the fields which identify the object, as they are not changed by the patch.
+func withoutIdentity(obj map[string]any) map[string]any {
+	stripped := unstructured.Unstructured{Object: obj}
+	stripped = *stripped.DeepCopy()
+	delete(stripped.Object, "apiVersion")
+	delete(stripped.Object, "kind")
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "name")
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "namespace")
+	if metadata, found, _ := unstructured.NestedMap(stripped.Object, "metadata"); found && len(metadata) == 0 {
+		delete(stripped.Object, "metadata")
+	}
+
+	return stripped.Object
+}
@@ -17,3 +17,9 @@ type Applier interface {
 type Creator interface {
 	Create() ([]Applier, error)
 }
+
+// Planner is an interface that allows to compute changes a set of resources would introduce to the cluster,
+// without actually applying them.
+type Planner interface {
+	Plan(ctx context.Context, cli client.Client, data map[string]any, options ...cluster.MetaOptions) ([]Change, error)
+}
@@ -0,0 +1,143 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RetryPolicy defines how long and how often conditions of the feature are checked before giving up.
+type RetryPolicy struct {
+	// Interval is the time to wait between the first and the second check of the condition.
+	Interval time.Duration
+	// Timeout is the total time after which waiting for the condition fails.
+	Timeout time.Duration
+	// Factor multiplies the interval after each unsuccessful check. Values lower or equal to 1 mean constant interval.
+	Factor float64
+	// MaxInterval caps the interval increased by the Factor. Zero means no limit.
+	MaxInterval time.Duration
+}
+
+// DefaultRetryPolicy is used for conditions of features which do not define their own policy.
+var DefaultRetryPolicy = RetryPolicy{ //nolint:gochecknoglobals // Reason: used as default value of the builder
+	Interval: 2 * time.Second,
+	Timeout:  5 * time.Minute,
+}
+
+// NotReadyError is returned by non-blocking features when the condition they wait for is not met yet.
+// Instead of treating it as a failure, the feature is reported as progressing and should be applied again later.
+type NotReadyError struct {
+	RequeueAfter time.Duration
+	err          error
+}
+
+func (e *NotReadyError) Unwrap() error {
+	return e.err
+}
+
+func (e *NotReadyError) Error() string {
+	return e.err.Error()
+}
+
+// IsNotReady checks if the error is caused only by conditions of non-blocking features which are not met yet,
+// and returns the time after which they should be checked again. When the error combines several errors, e.g. results
+// of all features of a handler, each of them has to be NotReadyError, so actual failures are not reported as progress.
+func IsNotReady(err error) (time.Duration, bool) {
+	var errs []error
+	switch typedErr := err.(type) { //nolint:errorlint // Reason: walks the error tree, checking each branch of it
+	case nil:
+		return 0, false
+	case *NotReadyError:
+		return typedErr.RequeueAfter, true
+	case *multierror.Error:
+		errs = typedErr.Errors
+	case interface{ Unwrap() []error }:
+		errs = typedErr.Unwrap()
+	case interface{ Unwrap() error }:
+		return IsNotReady(typedErr.Unwrap())
+	default:
+		return 0, false
+	}
+
+	if len(errs) == 0 {
+		return 0, false
+	}
+
+	requeueAfter := time.Duration(0)
+	for i, e := range errs {
+		after, notReady := IsNotReady(e)
+		if !notReady {
+			return 0, false
+		}
+		if i == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+	}
+
+	return requeueAfter, true
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy overrides the retry policy of the feature for the given condition.
+//
+//	PostConditions(
+//		feature.WithRetryPolicy(feature.RetryPolicy{Interval: 5 * time.Second, Timeout: 10 * time.Minute},
+//			feature.WaitForPodsToBeReady(namespace),
+//		),
+//	)
+func WithRetryPolicy(policy RetryPolicy, action Action) Action {
+	return func(ctx context.Context, cli client.Client, f *Feature) error {
+		return action(context.WithValue(ctx, retryPolicyKey{}, policy), cli, f)
+	}
+}
+
+// WaitFor polls the condition using the retry policy defined for the feature, or for the condition itself (see WithRetryPolicy).
+//
+// For non-blocking features the condition is checked only once. If it is not met, NotReadyError is returned,
+// so the feature can be reported as progressing and the caller can requeue the reconciliation instead of waiting.
+func (f *Feature) WaitFor(ctx context.Context, description string, condition wait.ConditionWithContextFunc) error {
+	policy := f.retryPolicy
+	if conditionPolicy, found := ctx.Value(retryPolicyKey{}).(RetryPolicy); found {
+		policy = conditionPolicy
+	}
+
+	if f.nonBlocking {
+		done, err := condition(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !done {
+			return &NotReadyError{
+				RequeueAfter: policy.Interval,
+				err:          fmt.Errorf("still waiting for %s", description),
+			}
+		}
+
+		return nil
+	}
+
+	f.Log.Info("waiting for "+description, "duration (s)", policy.Timeout.Seconds())
+
+	if policy.Factor <= 1 {
+		return wait.PollUntilContextTimeout(ctx, policy.Interval, policy.Timeout, false, condition)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, policy.Timeout)
+	defer cancel()
+
+	backoff := wait.Backoff{
+		Duration: policy.Interval,
+		Factor:   policy.Factor,
+		Cap:      policy.MaxInterval,
+		Steps:    math.MaxInt32,
+	}
+
+	return backoff.DelayFunc().Until(timeoutCtx, false, false, condition)
+}
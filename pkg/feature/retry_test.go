@@ -0,0 +1,153 @@
+package feature_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Waiting for conditions", func() {
+
+	neverMet := func(_ context.Context) (bool, error) {
+		return false, nil
+	}
+
+	It("should report not ready condition of non-blocking feature without waiting", func(ctx context.Context) {
+		// given
+		f, err := feature.Define("non-blocking").
+			TargetNamespace("test-namespace").
+			NonBlocking().
+			RetryPolicy(feature.RetryPolicy{Interval: 15 * time.Second, Timeout: time.Hour}).
+			Create()
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		waitErr := f.WaitFor(ctx, "condition which is never met", neverMet)
+
+		// then
+		requeueAfter, notReady := feature.IsNotReady(waitErr)
+		Expect(notReady).To(BeTrue())
+		Expect(requeueAfter).To(Equal(15 * time.Second))
+	})
+
+	It("should give up waiting after timeout defined by the retry policy", func(ctx context.Context) {
+		// given
+		f, err := feature.Define("blocking").
+			TargetNamespace("test-namespace").
+			RetryPolicy(feature.RetryPolicy{Interval: 10 * time.Millisecond, Timeout: 50 * time.Millisecond, Factor: 2}).
+			Create()
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		waitErr := f.WaitFor(ctx, "condition which is never met", neverMet)
+
+		// then
+		Expect(waitErr).To(MatchError(context.DeadlineExceeded))
+		_, notReady := feature.IsNotReady(waitErr)
+		Expect(notReady).To(BeFalse())
+	})
+
+	It("should prefer retry policy defined for the condition", func(ctx context.Context) {
+		// given
+		f, err := feature.Define("condition-policy").
+			TargetNamespace("test-namespace").
+			RetryPolicy(feature.RetryPolicy{Interval: time.Hour, Timeout: time.Hour}).
+			Create()
+		Expect(err).ToNot(HaveOccurred())
+
+		attempts := 0
+		condition := feature.WithRetryPolicy(feature.RetryPolicy{Interval: time.Millisecond, Timeout: time.Second},
+			func(ctx context.Context, _ client.Client, f *feature.Feature) error {
+				return f.WaitFor(ctx, "third attempt", func(_ context.Context) (bool, error) {
+					attempts++
+
+					return attempts == 3, nil
+				})
+			})
+
+		// when
+		Expect(condition(ctx, nil, f)).To(Succeed())
+
+		// then
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should not treat other errors as not ready", func() {
+		_, notReady := feature.IsNotReady(errors.New("failure"))
+		Expect(notReady).To(BeFalse())
+	})
+
+	It("should not treat failure as not ready when other feature of the handler is not ready", func(ctx context.Context) {
+		// given
+		scheme := runtime.NewScheme()
+		Expect(featurev1.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+
+		dsci := &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci", UID: "dsci-uid"},
+			Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+		}
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dsci).
+			WithStatusSubresource(&featurev1.FeatureTracker{}).
+			Build()
+
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define("not-ready").
+					NonBlocking().
+					PreConditions(func(ctx context.Context, _ client.Client, f *feature.Feature) error {
+						return f.WaitFor(ctx, "condition which is never met", neverMet)
+					}),
+				feature.Define("failing").
+					PreConditions(func(context.Context, client.Client, *feature.Feature) error {
+						return errors.New("precondition failed")
+					}),
+			)
+		})
+
+		// when
+		applyErr := featuresHandler.Apply(ctx, cli)
+
+		// then
+		Expect(applyErr).To(MatchError(ContainSubstring("precondition failed")))
+		_, notReady := feature.IsNotReady(applyErr)
+		Expect(notReady).To(BeFalse())
+	})
+
+	It("should report not ready when all features of the handler are not ready", func(ctx context.Context) {
+		// given
+		first, err := feature.Define("first").TargetNamespace("test-namespace").NonBlocking().
+			RetryPolicy(feature.RetryPolicy{Interval: 30 * time.Second, Timeout: time.Hour}).Create()
+		Expect(err).ToNot(HaveOccurred())
+		second, err := feature.Define("second").TargetNamespace("test-namespace").NonBlocking().
+			RetryPolicy(feature.RetryPolicy{Interval: 10 * time.Second, Timeout: time.Hour}).Create()
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		combinedErr := multierror.Append(
+			first.WaitFor(ctx, "condition which is never met", neverMet),
+			fmt.Errorf("wrapped: %w", second.WaitFor(ctx, "condition which is never met", neverMet)),
+		)
+
+		// then
+		requeueAfter, notReady := feature.IsNotReady(combinedErr)
+		Expect(notReady).To(BeTrue())
+		Expect(requeueAfter).To(Equal(10 * time.Second))
+	})
+})
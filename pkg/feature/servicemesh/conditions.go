@@ -3,14 +3,12 @@ package servicemesh
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
@@ -19,11 +17,6 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
 
-const (
-	interval = 2 * time.Second
-	duration = 5 * time.Minute
-)
-
 // EnsureAuthNamespaceExists creates a namespace for the Authorization provider and set ownership so it will be garbage collected when the operator is uninstalled.
 func EnsureAuthNamespaceExists(ctx context.Context, cli client.Client, f *feature.Feature) error {
 	authNs, err := FeatureData.Authorization.Namespace.Extract(f)
@@ -64,6 +57,10 @@ func EnsureServiceMeshInstalled(ctx context.Context, cli client.Client, f *featu
 	}
 
 	if err := WaitForControlPlaneToBeReady(ctx, cli, f); err != nil {
+		if _, notReady := feature.IsNotReady(err); notReady {
+			return err
+		}
+
 		controlPlane, errGet := FeatureData.ControlPlane.Extract(f)
 		if errGet != nil {
 			return fmt.Errorf("failed to get control plane struct: %w", err)
@@ -83,42 +80,41 @@ func WaitForControlPlaneToBeReady(ctx context.Context, cli client.Client, f *fea
 		return err
 	}
 
-	smcp := controlPlane.Name
-	smcpNs := controlPlane.Namespace
+	return feature.WaitUntil(
+		fmt.Sprintf("control plane components of %s/%s to be ready", controlPlane.Namespace, controlPlane.Name),
+		controlPlaneReady(controlPlane.Name, controlPlane.Namespace),
+	)(ctx, cli, f)
+}
 
-	f.Log.Info("waiting for control plane components to be ready", "control-plane", smcp, "namespace", smcpNs, "duration (s)", duration.Seconds())
+func CheckControlPlaneComponentReadiness(ctx context.Context, c client.Client, smcpName, smcpNs string) (bool, error) {
+	ready, _, err := controlPlaneReady(smcpName, smcpNs)(ctx, c)
 
-	return wait.PollUntilContextTimeout(ctx, interval, duration, false, func(ctx context.Context) (bool, error) {
-		ready, err := CheckControlPlaneComponentReadiness(ctx, cli, smcp, smcpNs)
+	return ready, err
+}
 
-		if ready {
-			f.Log.Info("done waiting for control plane components to be ready", "control-plane", smcp, "namespace", smcpNs)
+// controlPlaneReady checks if all the components of the control plane are ready. Missing control plane is reported
+// as an error right away, as it is not going to be created while waiting for it.
+func controlPlaneReady(smcpName, smcpNs string) feature.ResourceCheck {
+	return func(ctx context.Context, cli client.Client) (bool, string, error) {
+		smcp := &unstructured.Unstructured{}
+		smcp.SetGroupVersionKind(gvk.ServiceMeshControlPlane)
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: smcpNs, Name: smcpName}, smcp); err != nil {
+			return false, "", fmt.Errorf("failed to find Service Mesh Control Plane: %w", err)
 		}
 
-		return ready, err
-	})
-}
+		components, found, err := unstructured.NestedMap(smcp.Object, "status", "readiness", "components")
+		if err != nil || !found {
+			return false, "", fmt.Errorf("status conditions not found or error in parsing of Service Mesh Control Plane: %w", err)
+		}
 
-func CheckControlPlaneComponentReadiness(ctx context.Context, c client.Client, smcpName, smcpNs string) (bool, error) {
-	smcpObj := &unstructured.Unstructured{}
-	smcpObj.SetGroupVersionKind(gvk.ServiceMeshControlPlane)
-	err := c.Get(ctx, client.ObjectKey{
-		Namespace: smcpNs,
-		Name:      smcpName,
-	}, smcpObj)
+		count := func(state string) int {
+			items, _ := components[state].([]any)
 
-	if err != nil {
-		return false, fmt.Errorf("failed to find Service Mesh Control Plane: %w", err)
-	}
+			return len(items)
+		}
+		readyComponents, pendingComponents, unreadyComponents := count("ready"), count("pending"), count("unready")
+		progress := fmt.Sprintf("%d ready, %d pending, %d unready components", readyComponents, pendingComponents, unreadyComponents)
 
-	components, found, err := unstructured.NestedMap(smcpObj.Object, "status", "readiness", "components")
-	if err != nil || !found {
-		return false, fmt.Errorf("status conditions not found or error in parsing of Service Mesh Control Plane: %w", err)
+		return pendingComponents == 0 && unreadyComponents == 0 && readyComponents > 0, progress, nil
 	}
-
-	readyComponents := len(components["ready"].([]interface{}))     //nolint:forcetypeassert
-	pendingComponents := len(components["pending"].([]interface{})) //nolint:forcetypeassert
-	unreadyComponents := len(components["unready"].([]interface{})) //nolint:forcetypeassert
-
-	return pendingComponents == 0 && unreadyComponents == 0 && readyComponents > 0, nil
 }
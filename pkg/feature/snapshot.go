@@ -0,0 +1,206 @@
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-multierror"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+)
+
+// recordPatchSnapshots stores the original state of the fields which the applier is about to patch in the FeatureTracker status.
+// Fields which have been captured before are kept intact, so re-applying the feature does not override the original state.
+// Fields already holding the patched values, e.g. on clusters upgraded from an operator version which did not record
+// snapshots, are not reverted, as their original state is unknown, see resource.RevertPatchFor.
+func (f *Feature) recordPatchSnapshots(ctx context.Context, cli client.Client, snapshotter resource.Snapshotter) error {
+	snapshots, errSnapshot := snapshotter.Snapshot(ctx, cli, f.data)
+	if errSnapshot != nil {
+		return fmt.Errorf("failed capturing state of patched resources: %w", errSnapshot)
+	}
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	recorded, errMerge := mergePatchSnapshots(f.tracker.Status.PatchSnapshots, snapshots)
+	if errMerge != nil {
+		return errMerge
+	}
+
+	updated, updateErr := status.UpdateWithRetry(ctx, cli, f.tracker, func(saved *featurev1.FeatureTracker) {
+		saved.Status.PatchSnapshots = recorded
+	})
+	if updateErr != nil {
+		return fmt.Errorf("failed storing patch snapshots in FeatureTracker %s: %w", f.tracker.Name, updateErr)
+	}
+
+	f.tracker.Status.PatchSnapshots = updated.Status.PatchSnapshots
+
+	return nil
+}
+
+// revertPatches creates a CleanupFunc which restores the state of the objects patched by the feature
+// using snapshots recorded in the FeatureTracker. Objects which no longer exist are skipped.
+func revertPatches(f *Feature) CleanupFunc {
+	return func(ctx context.Context, cli client.Client) error {
+		tracker := f.tracker
+		if tracker == nil {
+			var errGet error
+			if tracker, errGet = getFeatureTracker(ctx, cli, f.Name, f.TargetNamespace); errGet != nil {
+				return client.IgnoreNotFound(errGet)
+			}
+		}
+
+		var revertErrors *multierror.Error
+		// Revert in the reverse order, so the objects patched first are restored last.
+		for i := len(tracker.Status.PatchSnapshots) - 1; i >= 0; i-- {
+			revertErrors = multierror.Append(revertErrors, revertPatch(ctx, cli, tracker.Status.PatchSnapshots[i]))
+		}
+
+		if revertErr := revertErrors.ErrorOrNil(); revertErr != nil {
+			return fmt.Errorf("failed reverting patches of feature '%s': %w", f.Name, revertErr)
+		}
+
+		return nil
+	}
+}
+
+func revertPatch(ctx context.Context, cli client.Client, snapshot featurev1.PatchSnapshot) error {
+	target := snapshot.Target
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(metav1.GroupVersion{Group: target.Group, Version: target.Version}.String())
+	obj.SetKind(target.Kind)
+	obj.SetNamespace(target.Namespace)
+	obj.SetName(target.Name)
+
+	errRevert := removeAddedEntries(ctx, cli, obj, snapshot.AddedEntries)
+	if errRevert == nil && snapshot.Revert != "" && snapshot.Revert != "{}" {
+		errRevert = cli.Patch(ctx, obj, client.RawPatch(k8stypes.MergePatchType, []byte(snapshot.Revert)))
+	}
+	if k8serr.IsNotFound(errRevert) || meta.IsNoMatchError(errRevert) {
+		return nil
+	}
+	if errRevert != nil {
+		return fmt.Errorf("failed to revert patch of %s %s/%s: %w", target.Kind, target.Namespace, target.Name, errRevert)
+	}
+
+	return nil
+}
+
+// removeAddedEntries removes the entries added by the patches from the lists of the object, keeping the entries added by others.
+func removeAddedEntries(ctx context.Context, cli client.Client, target *unstructured.Unstructured, entries []featurev1.ListEntries) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		obj := target.DeepCopy()
+		if errGet := cli.Get(ctx, client.ObjectKeyFromObject(target), obj); errGet != nil {
+			return errGet
+		}
+
+		removed, errRemove := resource.RemoveEntries(obj, entries)
+		if errRemove != nil || !removed {
+			return errRemove
+		}
+
+		return cli.Update(ctx, obj)
+	})
+}
+
+// mergePatchSnapshots adds new snapshots to the recorded ones. Snapshots of the same object are combined,
+// preferring already recorded state of the fields.
+func mergePatchSnapshots(recorded []featurev1.PatchSnapshot, snapshots []resource.Snapshot) ([]featurev1.PatchSnapshot, error) {
+	merged := append([]featurev1.PatchSnapshot{}, recorded...)
+
+	for _, snapshot := range snapshots {
+		gvk := snapshot.Target.GroupVersionKind()
+		target := featurev1.ResourceReference{
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Namespace: snapshot.Target.GetNamespace(),
+			Name:      snapshot.Target.GetName(),
+		}
+
+		index := -1
+		for i := range merged {
+			if containsReference([]featurev1.ResourceReference{merged[i].Target}, target) {
+				index = i
+
+				break
+			}
+		}
+
+		revert := snapshot.Revert
+		if index >= 0 {
+			existing := map[string]any{}
+			if errUnmarshal := json.Unmarshal([]byte(merged[index].Revert), &existing); errUnmarshal != nil {
+				return nil, fmt.Errorf("failed reading patch snapshot of %s %s/%s: %w", target.Kind, target.Namespace, target.Name, errUnmarshal)
+			}
+
+			resource.MergeMissing(existing, revert)
+			revert = existing
+		}
+
+		addedEntries := snapshot.AddedEntries
+		if index >= 0 {
+			addedEntries = mergeAddedEntries(merged[index].AddedEntries, addedEntries)
+		}
+
+		revertJSON, errMarshal := json.Marshal(revert)
+		if errMarshal != nil {
+			return nil, fmt.Errorf("failed serializing patch snapshot of %s %s/%s: %w", target.Kind, target.Namespace, target.Name, errMarshal)
+		}
+
+		if index >= 0 {
+			merged[index].Revert = string(revertJSON)
+			merged[index].AddedEntries = addedEntries
+
+			continue
+		}
+
+		merged = append(merged, featurev1.PatchSnapshot{Target: target, Revert: string(revertJSON), AddedEntries: addedEntries})
+	}
+
+	return merged, nil
+}
+
+// mergeAddedEntries adds the entries which have not been recorded yet.
+func mergeAddedEntries(recorded, added []featurev1.ListEntries) []featurev1.ListEntries {
+	merged := make([]featurev1.ListEntries, 0, len(recorded)+len(added))
+	for i := range recorded {
+		merged = append(merged, *recorded[i].DeepCopy())
+	}
+
+	for _, listEntries := range added {
+		index := slices.IndexFunc(merged, func(existing featurev1.ListEntries) bool {
+			return existing.Key == listEntries.Key && slices.Equal(existing.Field, listEntries.Field)
+		})
+		if index < 0 {
+			merged = append(merged, *listEntries.DeepCopy())
+
+			continue
+		}
+
+		for _, value := range listEntries.Values {
+			if !slices.Contains(merged[index].Values, value) {
+				merged[index].Values = append(merged[index].Values, value)
+			}
+		}
+	}
+
+	return merged
+}
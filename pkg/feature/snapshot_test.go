@@ -0,0 +1,181 @@
+package feature_test
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reverting patches", func() {
+
+	var current *unstructured.Unstructured
+
+	BeforeEach(func() {
+		current = &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "cm", "namespace": "ns"},
+			"data":       map[string]any{"existing": "value"},
+			"spec":       map[string]any{"list": []any{"a", "b"}},
+		}}
+	})
+
+	patchOf := func(fields map[string]any) *unstructured.Unstructured {
+		patch := &unstructured.Unstructured{Object: fields}
+		patch.SetAPIVersion("v1")
+		patch.SetKind("ConfigMap")
+		patch.SetNamespace("ns")
+		patch.SetName("cm")
+
+		return patch
+	}
+
+	It("should restore changed fields and remove added ones for merge patch", func() {
+		// given
+		patch := patchOf(map[string]any{
+			"data": map[string]any{"existing": "changed", "added": "value"},
+		})
+
+		// when
+		revert, _, err := resource.RevertPatchFor(current, k8stypes.MergePatchType, patch)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revert).To(Equal(map[string]any{
+			"data": map[string]any{"existing": "value", "added": nil},
+		}))
+	})
+
+	It("should not revert fields which already hold patched values", func() {
+		// given
+		patch := patchOf(map[string]any{
+			"data": map[string]any{"existing": "value", "added": "value"},
+			"spec": map[string]any{"list": []any{"a", "b"}},
+		})
+
+		// when
+		revert, _, err := resource.RevertPatchFor(current, k8stypes.MergePatchType, patch)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revert).To(Equal(map[string]any{
+			"data": map[string]any{"added": nil},
+		}))
+	})
+
+	It("should record entries added to the list instead of restoring whole list", func() {
+		// given
+		Expect(unstructured.SetNestedSlice(current.Object, []any{
+			map[string]any{"name": "existing", "value": "original"},
+		}, "spec", "providers")).To(Succeed())
+		patch := patchOf(map[string]any{
+			"spec": map[string]any{"providers": []any{
+				map[string]any{"name": "existing", "value": "original"},
+				map[string]any{"name": "added", "value": "by-patch"},
+			}},
+		})
+
+		// when
+		revert, addedEntries, err := resource.RevertPatchFor(current, k8stypes.MergePatchType, patch)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revert).To(BeEmpty())
+		Expect(addedEntries).To(Equal([]featurev1.ListEntries{
+			{Field: []string{"spec", "providers"}, Key: "name", Values: []string{"added"}},
+		}))
+	})
+
+	It("should record entry added to the list by JSON patch", func() {
+		// given
+		Expect(unstructured.SetNestedSlice(current.Object, []any{}, "spec", "providers")).To(Succeed())
+		patch := patchOf(map[string]any{
+			"patch": []any{
+				map[string]any{"op": "add", "path": "/spec/providers/-", "value": map[string]any{"name": "added"}},
+			},
+		})
+
+		// when
+		revert, addedEntries, err := resource.RevertPatchFor(current, k8stypes.JSONPatchType, patch)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revert).To(BeEmpty())
+		Expect(addedEntries).To(Equal([]featurev1.ListEntries{
+			{Field: []string{"spec", "providers"}, Key: "name", Values: []string{"added"}},
+		}))
+	})
+
+	It("should remove only added entries from the list", func() {
+		// given
+		Expect(unstructured.SetNestedSlice(current.Object, []any{
+			map[string]any{"name": "existing"},
+			map[string]any{"name": "added"},
+			map[string]any{"name": "added-later-by-others"},
+		}, "spec", "providers")).To(Succeed())
+
+		// when
+		removed, err := resource.RemoveEntries(current, []featurev1.ListEntries{
+			{Field: []string{"spec", "providers"}, Key: "name", Values: []string{"added"}},
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(removed).To(BeTrue())
+		providers, _, _ := unstructured.NestedSlice(current.Object, "spec", "providers")
+		Expect(providers).To(Equal([]any{
+			map[string]any{"name": "existing"},
+			map[string]any{"name": "added-later-by-others"},
+		}))
+	})
+
+	It("should remove top-most missing field", func() {
+		// given
+		patch := patchOf(map[string]any{
+			"status": map[string]any{"nested": map[string]any{"field": "value"}},
+		})
+
+		// when
+		revert, _, err := resource.RevertPatchFor(current, k8stypes.StrategicMergePatchType, patch)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revert).To(Equal(map[string]any{"status": nil}))
+	})
+
+	It("should restore whole list when its element is changed by JSON patch", func() {
+		// given
+		patch := patchOf(map[string]any{
+			"patch": []any{
+				map[string]any{"op": "add", "path": "/spec/list/-", "value": "c"},
+				map[string]any{"op": "remove", "path": "/data/existing"},
+			},
+		})
+
+		// when
+		revert, _, err := resource.RevertPatchFor(current, k8stypes.JSONPatchType, patch)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revert).To(Equal(map[string]any{
+			"spec": map[string]any{"list": []any{"a", "b"}},
+			"data": map[string]any{"existing": "value"},
+		}))
+	})
+
+	It("should keep previously captured state when merging snapshots", func() {
+		// given
+		revert := map[string]any{"data": map[string]any{"existing": "original"}}
+
+		// when
+		resource.MergeMissing(revert, map[string]any{"data": map[string]any{"existing": "patched", "added": nil}})
+
+		// then
+		Expect(revert).To(Equal(map[string]any{"data": map[string]any{"existing": "original", "added": nil}}))
+	})
+})
@@ -0,0 +1,124 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// ResourceCheck verifies if the resource in the cluster reached the desired state.
+// Progress describes the observed state of the resource, so it can be reported while waiting.
+type ResourceCheck func(ctx context.Context, cli client.Client) (done bool, progress string, err error)
+
+// WaitUntil creates an Action which waits until the check passes, using the retry policy of the feature.
+// Every change of the observed state is reported in the message of the FeatureTracker Progressing condition.
+func WaitUntil(description string, check ResourceCheck) Action {
+	return func(ctx context.Context, cli client.Client, f *Feature) error {
+		var progress string
+
+		waitErr := f.WaitFor(ctx, description, func(ctx context.Context) (bool, error) {
+			done, current, errCheck := check(ctx, cli)
+			if current != progress {
+				progress = current
+				f.reportProgress(ctx, cli, description, progress)
+			}
+
+			return done, errCheck
+		})
+
+		if waitErr == nil || progress == "" {
+			return waitErr
+		}
+
+		if _, notReady := IsNotReady(waitErr); notReady {
+			return fmt.Errorf("%w (%s)", waitErr, progress)
+		}
+
+		return fmt.Errorf("failed waiting for %s (%s): %w", description, progress, waitErr)
+	}
+}
+
+// WaitForCRDEstablished waits until the CustomResourceDefinition of the given name is established.
+func WaitForCRDEstablished(name string) Action {
+	return WaitUntil("CRD "+name+" to be established", ConditionIs(gvk.CustomResourceDefinition, "", name, "Established", "True"))
+}
+
+// ConditionIs checks if the condition of the given type, found in status.conditions of the object, has the expected status.
+func ConditionIs(objectGVK schema.GroupVersionKind, namespace, name, conditionType, expectedStatus string) ResourceCheck {
+	return func(ctx context.Context, cli client.Client) (bool, string, error) {
+		obj, found, errGet := getObject(ctx, cli, objectGVK, namespace, name)
+		if errGet != nil || !found {
+			return false, objectGVK.Kind + " not found", errGet
+		}
+
+		conditions, _, errConditions := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		if errConditions != nil {
+			return false, "", fmt.Errorf("failed reading conditions of %s %s: %w", objectGVK.Kind, key(namespace, name), errConditions)
+		}
+
+		for _, item := range conditions {
+			condition, isMap := item.(map[string]any)
+			if !isMap || condition["type"] != conditionType {
+				continue
+			}
+
+			actualStatus, _ := condition["status"].(string)
+			progress := fmt.Sprintf("%s=%s", conditionType, actualStatus)
+			if reason, hasReason := condition["reason"].(string); hasReason && reason != "" {
+				progress += " (" + reason + ")"
+			}
+
+			return strings.EqualFold(actualStatus, expectedStatus), progress, nil
+		}
+
+		return false, fmt.Sprintf("%s condition not reported", conditionType), nil
+	}
+}
+
+// reportProgress stores the observed state of the awaited resource in the FeatureTracker Progressing condition.
+// Failing to do so does not interrupt waiting, as the progress is informative only.
+func (f *Feature) reportProgress(ctx context.Context, cli client.Client, description, progress string) {
+	if f.tracker == nil || progress == "" {
+		return
+	}
+
+	message := fmt.Sprintf("Applying feature [%s]: waiting for %s: %s", f.Name, description, progress)
+	if _, updateErr := status.UpdateWithRetry(ctx, cli, f.tracker, func(saved *featurev1.FeatureTracker) {
+		status.SetProgressingCondition(&saved.Status.Conditions, string(featurev1.ConditionReason.FeatureCreated), message)
+		saved.Status.Phase = status.PhaseProgressing
+	}); updateErr != nil {
+		f.Log.Error(updateErr, "failed reporting progress", "progress", message)
+	}
+}
+
+func getObject(ctx context.Context, cli client.Client, objectGVK schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, bool, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(objectGVK)
+
+	errGet := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj)
+	if k8serr.IsNotFound(errGet) {
+		return nil, false, nil
+	}
+	if errGet != nil {
+		return nil, false, fmt.Errorf("failed to get %s %s: %w", objectGVK.Kind, key(namespace, name), errGet)
+	}
+
+	return obj, true, nil
+}
+
+func key(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "/" + name
+}
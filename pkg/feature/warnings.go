@@ -0,0 +1,89 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+)
+
+// WarningError carries failures of the checks defined using featureBuilder.Warnings.
+// Such failures do not prevent the feature from being applied, but are worth reporting to the cluster admin.
+type WarningError struct {
+	err error
+}
+
+func (e *WarningError) Unwrap() error {
+	return e.err
+}
+
+func (e *WarningError) Error() string {
+	return e.err.Error()
+}
+
+// IsWarning checks if the error reports only failed warning checks of the features.
+func IsWarning(err error) bool {
+	var warningErr *WarningError
+
+	return errors.As(err, &warningErr)
+}
+
+// checkWarnings invokes all warning checks of the feature and keeps their failures, so they can be reported
+// once the feature is applied.
+func (f *Feature) checkWarnings(ctx context.Context, cli client.Client) {
+	var multiErr *multierror.Error
+	for _, warning := range f.warnings {
+		multiErr = multierror.Append(multiErr, warning(ctx, cli, f))
+	}
+
+	f.warningsChecked = true
+	f.warningsErr = nil
+	if warningsErr := multiErr.ErrorOrNil(); warningsErr != nil {
+		f.Log.Info("feature applied with warnings", "warnings", warningsErr.Error())
+		f.warningsErr = &WarningError{err: warningsErr}
+	}
+}
+
+// addWarning adds the failure to the warnings of the feature, e.g. conflicts with other field managers
+// detected while applying its manifests.
+func (f *Feature) addWarning(err error) {
+	if err == nil {
+		return
+	}
+
+	var multiErr *multierror.Error
+	if warningErr, isWarning := f.warningsErr.(*WarningError); isWarning {
+		multiErr = multierror.Append(multiErr, warningErr.err)
+	}
+
+	f.warningsChecked = true
+	f.warningsErr = &WarningError{err: multierror.Append(multiErr, err)}
+}
+
+// reportWarnings sets Degraded condition of the FeatureTracker when any of the warning checks failed and removes it otherwise.
+// It is used only for successfully applied features, as Degraded condition of the failed ones reports the failure.
+// The condition is left untouched when the checks have not been invoked, e.g. when the feature was skipped.
+func (f *Feature) reportWarnings(conditions *[]conditionsv1.Condition) {
+	if !f.warningsChecked {
+		return
+	}
+
+	if f.warningsErr == nil {
+		conditionsv1.RemoveStatusCondition(conditions, conditionsv1.ConditionDegraded)
+
+		return
+	}
+
+	conditionsv1.SetStatusCondition(conditions, conditionsv1.Condition{
+		Type:    conditionsv1.ConditionDegraded,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(featurev1.ConditionReason.Warnings),
+		Message: fmt.Sprintf("Feature [%s] has warnings: %+v", f.Name, f.warningsErr),
+	})
+}
@@ -0,0 +1,96 @@
+package feature_test
+
+import (
+	"context"
+	"errors"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reporting warnings", func() {
+
+	var (
+		dsci *dsciv1.DSCInitialization
+		cli  client.Client
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(featurev1.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+
+		dsci = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci", UID: "dsci-uid"},
+			Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "test-ns"},
+		}
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dsci).
+			WithStatusSubresource(&featurev1.FeatureTracker{}).
+			Build()
+	})
+
+	failing := func(message string) feature.Action {
+		return func(_ context.Context, _ client.Client, _ *feature.Feature) error {
+			return errors.New(message)
+		}
+	}
+
+	degradedConditionOf := func(ctx context.Context, featureName string) *conditionsv1.Condition {
+		GinkgoHelper()
+
+		tracker := &featurev1.FeatureTracker{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "test-ns-" + featureName}, tracker)).To(Succeed())
+
+		return conditionsv1.FindStatusCondition(tracker.Status.Conditions, conditionsv1.ConditionDegraded)
+	}
+
+	It("should keep failure reason of the feature without warnings", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("precondition-fail").PreConditions(failing("precondition failed")))
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, cli)).ToNot(Succeed())
+
+		// then
+		degraded := degradedConditionOf(ctx, "precondition-fail")
+		Expect(degraded).ToNot(BeNil())
+		Expect(degraded.Status).To(Equal(corev1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal(string(featurev1.ConditionReason.PreConditions)))
+	})
+
+	It("should report failure of the feature rather than its warnings", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("failing-with-warnings").
+				Warnings(failing("optional check failed")).
+				PreConditions(failing("precondition failed")),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, cli)).ToNot(Succeed())
+
+		// then
+		degraded := degradedConditionOf(ctx, "failing-with-warnings")
+		Expect(degraded).ToNot(BeNil())
+		Expect(degraded.Reason).To(Equal(string(featurev1.ConditionReason.PreConditions)))
+		Expect(degraded.Message).To(ContainSubstring("precondition failed"))
+	})
+})
@@ -13,3 +13,15 @@ const (
 	SecretLengthAnnotation      = "secret-generator.opendatahub.io/complexity"
 	SecretOauthClientAnnotation = "secret-generator.opendatahub.io/oauth-client-route"
 )
+
+// ConflictPolicy defines how the operator resolves conflicts with other field managers (e.g. GitOps tooling)
+// when applying the resource using server-side apply. Either "Force" (default) or "Yield".
+const ConflictPolicy = "opendatahub.io/conflict-policy"
+
+// ManifestsPlan requests a report of changes which deploying component manifests would introduce to the cluster,
+// computed using server-side dry-run. The report is generated again whenever the value or the operator release changes.
+const ManifestsPlan = "opendatahub.io/manifests-plan"
+
+// ManifestsPlanOnly holds deployment of component manifests while set to "true", so the report requested using
+// ManifestsPlan can be reviewed first, e.g. after upgrading the operator.
+const ManifestsPlanOnly = "opendatahub.io/manifests-plan-only"
@@ -0,0 +1,441 @@
+// Package oci provides minimal support for fetching manifests bundled as OCI artifacts from container registries,
+// using the OCI distribution API directly.
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// annotationTitle names the file stored as a single layer, e.g. when pushed using ORAS.
+	annotationTitle = "org.opencontainers.image.title"
+
+	// acceptedManifests lists media types of the manifests the client can process.
+	acceptedManifests = mediaTypeOCIManifest + ", " + mediaTypeOCIIndex + ", " + mediaTypeDockerManifest + ", " + mediaTypeDockerList
+
+	// maxBlobSize limits size of the fetched manifest and layers, as bundles are expected to contain only YAML files.
+	maxBlobSize = 32 << 20
+
+	// DefaultMaxArtifactSize limits the total size of the layers of the artifact and of the files extracted from them.
+	DefaultMaxArtifactSize = 128 << 20
+)
+
+// Reference identifies an artifact in a container registry.
+type Reference struct {
+	Registry   string
+	Repository string
+	// Tag or digest of the artifact.
+	Reference string
+}
+
+func (r Reference) String() string {
+	separator := ":"
+	if strings.HasPrefix(r.Reference, "sha256:") {
+		separator = "@"
+	}
+
+	return r.Registry + "/" + r.Repository + separator + r.Reference
+}
+
+// ParseReference parses image reference such as quay.io/org/bundle:v1 or quay.io/org/bundle@sha256:<digest>.
+// The "oci://" prefix is optional and references without tag point to "latest".
+func ParseReference(image string) (Reference, error) {
+	image = strings.TrimPrefix(image, "oci://")
+
+	registry, repository, found := strings.Cut(image, "/")
+	if !found || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		registry, repository = "registry-1.docker.io", image
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+
+	ref := Reference{Registry: registry, Repository: repository, Reference: "latest"}
+	if name, digest, isDigest := strings.Cut(repository, "@"); isDigest {
+		ref.Repository, ref.Reference = name, digest
+	} else if lastSlash := strings.LastIndex(repository, "/"); strings.Contains(repository[lastSlash+1:], ":") {
+		tagSeparator := strings.LastIndex(repository, ":")
+		ref.Repository, ref.Reference = repository[:tagSeparator], repository[tagSeparator+1:]
+	}
+
+	if ref.Repository == "" || ref.Reference == "" {
+		return Reference{}, fmt.Errorf("invalid OCI reference %q", image)
+	}
+
+	return ref, nil
+}
+
+// Client fetches artifacts from container registries.
+type Client struct {
+	HTTPClient *http.Client
+	// PlainHTTP makes the client talk to the registry without TLS. Intended for local registries only.
+	PlainHTTP bool
+	// Username and Password are used to obtain a token from the registry. Anonymous access is used when empty.
+	Username, Password string
+	// MaxArtifactSize limits the total size of the pulled artifact, DefaultMaxArtifactSize is used when not set.
+	MaxArtifactSize int64
+}
+
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Manifests []descriptor `json:"manifests,omitempty"`
+	Layers    []descriptor `json:"layers,omitempty"`
+}
+
+// Artifact is the content of the artifact pulled from the registry.
+type Artifact struct {
+	// Digest of the artifact manifest, e.g. sha256:<hex>.
+	Digest string
+	// Files contained in the artifact, keyed by their path.
+	Files map[string][]byte
+}
+
+// Fetch pulls the artifact and returns the files it contains, keyed by their path.
+// Layers which are tar archives (optionally gzipped) are extracted, other layers are stored as files
+// named after their title annotation.
+func (c *Client) Fetch(ctx context.Context, image string) (map[string][]byte, error) {
+	artifact, err := c.Pull(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifact.Files, nil
+}
+
+// Pull pulls the artifact together with the digest of its manifest, so its content can be verified. See Fetch for details.
+// Manifests referenced by an index and all the layers are verified against the digest and the size of their descriptors,
+// so the digest of the manifest identifies the whole content of the artifact.
+func (c *Client) Pull(ctx context.Context, image string) (*Artifact, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := c.get(ctx, ref, "manifests/"+ref.Reference, acceptedManifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching manifest of %s: %w", ref, err)
+	}
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+
+	if strings.HasPrefix(ref.Reference, "sha256:") && ref.Reference != digest {
+		return nil, fmt.Errorf("digest %s of the manifest of %s does not match the reference", digest, ref)
+	}
+
+	imageManifest, err := c.resolveManifest(ctx, ref, content)
+	if err != nil {
+		return nil, err
+	}
+
+	maxArtifactSize := c.MaxArtifactSize
+	if maxArtifactSize <= 0 {
+		maxArtifactSize = DefaultMaxArtifactSize
+	}
+
+	layersSize := int64(0)
+	for _, layer := range imageManifest.Layers {
+		if layer.Size < 0 || layer.Size > maxArtifactSize-layersSize {
+			return nil, fmt.Errorf("layers of %s exceed the limit of %d bytes", ref, maxArtifactSize)
+		}
+		layersSize += layer.Size
+	}
+
+	extracted := &extraction{files: make(map[string][]byte), remaining: maxArtifactSize}
+	for _, layer := range imageManifest.Layers {
+		blob, errBlob := c.get(ctx, ref, "blobs/"+layer.Digest, "")
+		if errBlob != nil {
+			return nil, fmt.Errorf("failed fetching layer %s of %s: %w", layer.Digest, ref, errBlob)
+		}
+
+		if errVerify := verify(layer, blob); errVerify != nil {
+			return nil, fmt.Errorf("failed verifying layer of %s: %w", ref, errVerify)
+		}
+
+		if errExtract := extracted.addLayer(layer, blob); errExtract != nil {
+			return nil, fmt.Errorf("failed extracting layer %s of %s: %w", layer.Digest, ref, errExtract)
+		}
+	}
+
+	return &Artifact{Digest: digest, Files: extracted.files}, nil
+}
+
+// verify checks that the content fetched from the registry matches the size and the digest of its descriptor.
+func verify(desc descriptor, content []byte) error {
+	if int64(len(content)) != desc.Size {
+		return fmt.Errorf("size %d of %s does not match the expected size %d", len(content), desc.Digest, desc.Size)
+	}
+
+	algorithm, encoded, _ := strings.Cut(desc.Digest, ":")
+	if algorithm != "sha256" {
+		return fmt.Errorf("unsupported digest %q", desc.Digest)
+	}
+
+	if actual := fmt.Sprintf("%x", sha256.Sum256(content)); actual != encoded {
+		return fmt.Errorf("content digest sha256:%s does not match the expected %s", actual, desc.Digest)
+	}
+
+	return nil
+}
+
+// resolveManifest decodes the manifest, following the index to the manifest of the image if needed.
+func (c *Client) resolveManifest(ctx context.Context, ref Reference, content []byte) (*manifest, error) {
+	artifact := &manifest{}
+	if errDecode := json.Unmarshal(content, artifact); errDecode != nil {
+		return nil, fmt.Errorf("failed decoding manifest of %s: %w", ref, errDecode)
+	}
+
+	if len(artifact.Manifests) == 0 {
+		return artifact, nil
+	}
+
+	// Manifest bundles are platform independent, so any of the indexed manifests can be used.
+	indexed, err := c.get(ctx, ref, "manifests/"+artifact.Manifests[0].Digest, acceptedManifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching manifest of %s: %w", ref, err)
+	}
+
+	if errVerify := verify(artifact.Manifests[0], indexed); errVerify != nil {
+		return nil, fmt.Errorf("failed verifying manifest of %s: %w", ref, errVerify)
+	}
+
+	return c.resolveManifest(ctx, ref, indexed)
+}
+
+func (c *Client) get(ctx context.Context, ref Reference, resource, accept string) ([]byte, error) {
+	scheme := "https"
+	if c.PlainHTTP {
+		scheme = "http"
+	}
+	endpoint := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Registry, ref.Repository, resource)
+
+	resp, err := c.do(ctx, endpoint, accept, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		token, errToken := c.token(ctx, challenge)
+		if errToken != nil {
+			return nil, errToken
+		}
+
+		if resp, err = c.do(ctx, endpoint, accept, token); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %d of %s", resp.StatusCode, endpoint)
+	}
+
+	return readLimited(resp.Body, maxBlobSize)
+}
+
+func (c *Client) do(ctx context.Context, endpoint, accept, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return httpClient.Do(req)
+}
+
+// token obtains bearer token from the auth service advertised in WWW-Authenticate challenge of the registry.
+func (c *Client) token(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry authentication %q", scheme)
+	}
+
+	attributes := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		if key, value, found := strings.Cut(strings.TrimSpace(param), "="); found {
+			attributes[key] = strings.Trim(value, `"`)
+		}
+	}
+
+	realm, err := url.Parse(attributes["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid registry authentication realm %q", attributes["realm"])
+	}
+
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value := attributes[key]; value != "" {
+			query.Set(key, value)
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed obtaining registry token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed obtaining registry token: unexpected HTTP status %d", resp.StatusCode)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if errDecode := json.NewDecoder(resp.Body).Decode(&tokenResponse); errDecode != nil {
+		return "", fmt.Errorf("failed decoding registry token: %w", errDecode)
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+
+	return tokenResponse.AccessToken, nil
+}
+
+// extraction collects files extracted from the layers of the artifact, limiting their total size.
+type extraction struct {
+	files     map[string][]byte
+	remaining int64
+}
+
+func (e *extraction) addLayer(layer descriptor, blob []byte) error {
+	switch {
+	case strings.HasSuffix(layer.MediaType, "gzip"):
+		gzipReader, err := gzip.NewReader(bytes.NewReader(blob))
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+
+		return e.addTar(gzipReader)
+	case strings.HasSuffix(layer.MediaType, "tar"):
+		return e.addTar(bytes.NewReader(blob))
+	default:
+		title := layer.Annotations[annotationTitle]
+		if title == "" {
+			return fmt.Errorf("unsupported layer media type %q without %s annotation", layer.MediaType, annotationTitle)
+		}
+
+		name, err := cleanPath(title)
+		if err != nil {
+			return err
+		}
+
+		return e.add(name, bytes.NewReader(blob))
+	}
+}
+
+func (e *extraction) addTar(reader io.Reader) error {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name, err := cleanPath(header.Name)
+		if err != nil {
+			return err
+		}
+
+		if err := e.add(name, tarReader); err != nil {
+			return fmt.Errorf("failed reading %s: %w", name, err)
+		}
+	}
+}
+
+func (e *extraction) add(name string, reader io.Reader) error {
+	e.remaining += int64(len(e.files[name]))
+
+	content, err := readLimited(reader, min(maxBlobSize, e.remaining))
+	if err != nil {
+		return err
+	}
+
+	e.remaining -= int64(len(content))
+	e.files[name] = content
+
+	return nil
+}
+
+// cleanPath ensures that the file stays within the root of the extracted artifact.
+func cleanPath(name string) (string, error) {
+	cleaned := path.Clean("/" + name)[1:]
+	if cleaned == "" || cleaned != strings.TrimPrefix(path.Clean(name), "/") {
+		return "", fmt.Errorf("invalid file path %q in the artifact", name)
+	}
+
+	return cleaned, nil
+}
+
+func readLimited(reader io.Reader, limit int64) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("content exceeds the limit of %d bytes", limit)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,216 @@
+package oci_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/oci"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parsing OCI references", func() {
+
+	DescribeTable("should resolve registry, repository and reference",
+		func(image string, expected oci.Reference) {
+			ref, err := oci.ParseReference(image)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ref).To(Equal(expected))
+		},
+		Entry("tagged image", "quay.io/org/bundle:v1",
+			oci.Reference{Registry: "quay.io", Repository: "org/bundle", Reference: "v1"}),
+		Entry("image with oci scheme", "oci://quay.io/org/bundle:v1",
+			oci.Reference{Registry: "quay.io", Repository: "org/bundle", Reference: "v1"}),
+		Entry("image without tag", "quay.io/org/bundle",
+			oci.Reference{Registry: "quay.io", Repository: "org/bundle", Reference: "latest"}),
+		Entry("image pinned by digest", "quay.io/org/bundle@sha256:abc",
+			oci.Reference{Registry: "quay.io", Repository: "org/bundle", Reference: "sha256:abc"}),
+		Entry("registry with port", "localhost:5000/bundle:v2",
+			oci.Reference{Registry: "localhost:5000", Repository: "bundle", Reference: "v2"}),
+		Entry("docker hub image", "bundle:v3",
+			oci.Reference{Registry: "registry-1.docker.io", Repository: "library/bundle", Reference: "v3"}),
+	)
+
+	It("should reject reference without repository", func() {
+		_, err := oci.ParseReference("quay.io/")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Fetching OCI artifacts", func() {
+
+	var (
+		registry *httptest.Server
+		blobs    map[string][]byte
+		layers   []map[string]any
+		client   *oci.Client
+	)
+
+	addLayer := func(mediaType string, content []byte, annotations map[string]string) map[string]any {
+		digest := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+		blobs[digest] = content
+		layer := map[string]any{"mediaType": mediaType, "digest": digest, "size": len(content)}
+		if annotations != nil {
+			layer["annotations"] = annotations
+		}
+		layers = append(layers, layer)
+
+		return layer
+	}
+
+	BeforeEach(func() {
+		blobs = map[string][]byte{}
+		layers = nil
+
+		registry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/v2/org/bundle/manifests/v1":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"schemaVersion": 2,
+					"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+					"layers":        layers,
+				})
+			case strings.HasPrefix(r.URL.Path, "/v2/org/bundle/blobs/"):
+				blob, found := blobs[strings.TrimPrefix(r.URL.Path, "/v2/org/bundle/blobs/")]
+				if !found {
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				_, _ = w.Write(blob)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(registry.Close)
+
+		client = &oci.Client{HTTPClient: registry.Client(), PlainHTTP: true}
+	})
+
+	image := func() string {
+		return strings.TrimPrefix(registry.URL, "http://") + "/org/bundle:v1"
+	}
+
+	It("should extract files from gzipped tar layer", func(ctx context.Context) {
+		// given
+		archive := &bytes.Buffer{}
+		gzipWriter := gzip.NewWriter(archive)
+		tarWriter := tar.NewWriter(gzipWriter)
+		content := []byte("apiVersion: v1\nkind: ConfigMap\n")
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: "manifests/cm.yaml", Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tarWriter.Write(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzipWriter.Close()).To(Succeed())
+
+		addLayer("application/vnd.oci.image.layer.v1.tar+gzip", archive.Bytes(), nil)
+
+		// when
+		files, err := client.Fetch(ctx, image())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveKeyWithValue("manifests/cm.yaml", content))
+	})
+
+	It("should store single file layer using its title", func(ctx context.Context) {
+		// given
+		content := []byte("apiVersion: v1\nkind: Secret\n")
+		addLayer("application/yaml", content, map[string]string{"org.opencontainers.image.title": "secret.yaml"})
+
+		// when
+		files, err := client.Fetch(ctx, image())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveKeyWithValue("secret.yaml", content))
+	})
+
+	It("should reject files escaping the artifact root", func(ctx context.Context) {
+		// given
+		addLayer("application/yaml", []byte("kind: Secret"), map[string]string{"org.opencontainers.image.title": "../../etc/passwd"})
+
+		// when
+		_, err := client.Fetch(ctx, image())
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("invalid file path")))
+	})
+
+	It("should reject layer which does not match its digest", func(ctx context.Context) {
+		// given
+		layer := addLayer("application/yaml", []byte("kind: Secret"), map[string]string{"org.opencontainers.image.title": "secret.yaml"})
+		blobs[layer["digest"].(string)] = []byte("kind: Secreq")
+
+		// when
+		_, err := client.Fetch(ctx, image())
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("does not match the expected " + layer["digest"].(string))))
+	})
+
+	It("should reject layer which does not match its size", func(ctx context.Context) {
+		// given
+		layer := addLayer("application/yaml", []byte("kind: Secret"), map[string]string{"org.opencontainers.image.title": "secret.yaml"})
+		layer["size"] = 5
+
+		// when
+		_, err := client.Fetch(ctx, image())
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("does not match the expected size 5")))
+	})
+
+	It("should reject artifact exceeding the size limit", func(ctx context.Context) {
+		// given
+		client.MaxArtifactSize = 16
+		addLayer("application/yaml", []byte("kind: ConfigMap\n"), map[string]string{"org.opencontainers.image.title": "cm.yaml"})
+		addLayer("application/yaml", []byte("kind: Secret\n"), map[string]string{"org.opencontainers.image.title": "secret.yaml"})
+
+		// when
+		_, err := client.Fetch(ctx, image())
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("exceed the limit of 16 bytes")))
+	})
+
+	It("should limit size of files extracted from compressed layers", func(ctx context.Context) {
+		// given
+		client.MaxArtifactSize = 1024
+		archive := &bytes.Buffer{}
+		gzipWriter := gzip.NewWriter(archive)
+		tarWriter := tar.NewWriter(gzipWriter)
+		content := bytes.Repeat([]byte("a"), 4096)
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: "large.yaml", Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tarWriter.Write(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzipWriter.Close()).To(Succeed())
+		addLayer("application/vnd.oci.image.layer.v1.tar+gzip", archive.Bytes(), nil)
+
+		// when
+		_, err = client.Fetch(ctx, image())
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("content exceeds the limit")))
+	})
+
+	It("should fail when the artifact does not exist", func(ctx context.Context) {
+		// when
+		_, err := client.Fetch(ctx, strings.TrimPrefix(registry.URL, "http://")+"/org/missing:v1")
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("unexpected HTTP status 404")))
+	})
+})
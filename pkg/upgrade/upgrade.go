@@ -210,7 +210,8 @@ func getDashboardWatsonResources(ns string) []ResourceSpec {
 	}
 }
 
-// TODO: remove function once we have a generic solution across all components.
+// CleanupExistingResource removes resources deprecated in previous releases, which are not pruned together with
+// the component manifests, i.e. resources deployed by v1 or outside of DeployManifestsFromPath.
 func CleanupExistingResource(ctx context.Context,
 	cli client.Client,
 	platform cluster.Platform,
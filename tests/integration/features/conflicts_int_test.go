@@ -0,0 +1,125 @@
+package features_test
+
+import (
+	"context"
+	"testing/fstest"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Conflicts with other field managers", func() {
+
+	const gitOpsManager = "argocd-controller"
+
+	var (
+		appNamespace string
+		dsci         *dsciv1.DSCInitialization
+	)
+
+	manifests := fstest.MapFS{
+		"settings.tmpl.yaml": &fstest.MapFile{Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: {{ .TargetNamespace }}
+  annotations:
+    opendatahub.io/managed: "true"
+data:
+  replicas: "1"
+  timeout: "30s"
+`)},
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		appNamespace = envtestutil.AppendRandomNameTo("conflicts")
+		Expect(fixtures.CreateOrUpdateNamespace(ctx, envTestClient, fixtures.NewNamespace(appNamespace))).To(Succeed())
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, envtestutil.AppendRandomNameTo("dsci-conflicts"), appNamespace)
+	})
+
+	// applyAsGitOps changes the settings the way GitOps tooling would, using its own field manager.
+	applyAsGitOps := func(ctx context.Context, policy cluster.ConflictPolicy) {
+		settings := &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "settings",
+				Namespace:   appNamespace,
+				Annotations: map[string]string{annotations.ConflictPolicy: string(policy)},
+			},
+			Data: map[string]string{"replicas": "3"},
+		}
+		Expect(envTestClient.Patch(ctx, settings, client.Apply, client.FieldOwner(gitOpsManager), client.ForceOwnership)).To(Succeed())
+	}
+
+	settingsFeature := func() *feature.FeaturesHandler {
+		return feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("conflicting-settings").
+				Manifests(manifest.Location(manifests).Include(".")),
+			)
+		})
+	}
+
+	settingsData := func(ctx context.Context) map[string]string {
+		settings := &corev1.ConfigMap{}
+		Expect(envTestClient.Get(ctx, client.ObjectKey{Namespace: appNamespace, Name: "settings"}, settings)).To(Succeed())
+
+		return settings.Data
+	}
+
+	degradedByConflict := MatchFields(IgnoreExtras, Fields{
+		"Type":    Equal(conditionsv1.ConditionDegraded),
+		"Status":  Equal(corev1.ConditionTrue),
+		"Reason":  Equal(string(featurev1.ConditionReason.Warnings)),
+		"Message": And(ContainSubstring(".data.replicas"), ContainSubstring(gitOpsManager)),
+	})
+
+	It("should force the ownership by default and report the conflict", func(ctx context.Context) {
+		// given
+		Expect(settingsFeature().Apply(ctx, envTestClient)).To(Succeed())
+		applyAsGitOps(ctx, cluster.ConflictPolicyForce)
+
+		// when
+		Expect(settingsFeature().Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(settingsData(ctx)).To(HaveKeyWithValue("replicas", "1"))
+
+		featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "conflicting-settings")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(featureTracker.Status.Conditions).To(ContainElement(degradedByConflict))
+	})
+
+	It("should yield conflicting fields when requested by the policy of the resource", func(ctx context.Context) {
+		// given
+		Expect(settingsFeature().Apply(ctx, envTestClient)).To(Succeed())
+		applyAsGitOps(ctx, cluster.ConflictPolicyYield)
+
+		// when
+		Expect(settingsFeature().Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(settingsData(ctx)).To(And(
+			HaveKeyWithValue("replicas", "3"),
+			HaveKeyWithValue("timeout", "30s"),
+		))
+
+		featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "conflicting-settings")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(featureTracker.Status.Conditions).To(ContainElement(degradedByConflict))
+	})
+})
@@ -0,0 +1,131 @@
+package features_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Applying dependent features", func() {
+
+	var (
+		appNamespace string
+		dsci         *dsciv1.DSCInitialization
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		appNamespace = envtestutil.AppendRandomNameTo("app-namespace")
+		dsciName := envtestutil.AppendRandomNameTo("dsci-" + appNamespace)
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, appNamespace)
+	})
+
+	It("should apply feature only after its dependency is applied", func(ctx context.Context) {
+		// given
+		var dependencyApplied atomic.Bool
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define("dependent-feature").
+					DependsOn("dependency-feature").
+					PreConditions(func(_ context.Context, _ client.Client, _ *feature.Feature) error {
+						if !dependencyApplied.Load() {
+							return errors.New("dependency has not been applied yet")
+						}
+
+						return nil
+					}),
+				feature.Define("dependency-feature").
+					PostConditions(func(_ context.Context, _ client.Client, _ *feature.Feature) error {
+						dependencyApplied.Store(true)
+
+						return nil
+					}),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "dependent-feature")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(featureTracker.Status.Phase).To(Equal(status.PhaseReady))
+	})
+
+	It("should skip feature and report it in its FeatureTracker when dependency fails", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define("failing-dependency").
+					PreConditions(func(_ context.Context, _ client.Client, _ *feature.Feature) error {
+						return errors.New("during test always fail")
+					}),
+				feature.Define("skipped-feature").
+					DependsOn("failing-dependency"),
+				feature.Define("transitively-skipped-feature").
+					DependsOn("skipped-feature"),
+				feature.Define("independent-feature"),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).ToNot(Succeed())
+
+		// then
+		for _, skippedFeature := range []string{"skipped-feature", "transitively-skipped-feature"} {
+			featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, skippedFeature)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(featureTracker.Status.Phase).To(Equal(status.PhaseError))
+			Expect(featureTracker.Status.Conditions).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(conditionsv1.ConditionDegraded),
+					"Status": Equal(corev1.ConditionTrue),
+					"Reason": Equal(string(featurev1.ConditionReason.DependencyFailed)),
+				}),
+			))
+		}
+
+		independentTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "independent-feature")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(independentTracker.Status.Phase).To(Equal(status.PhaseReady))
+	})
+
+	It("should clean up dependent feature before its dependency", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define("dependency-to-clean-up"),
+				feature.Define("dependent-to-clean-up").
+					DependsOn("dependency-to-clean-up").
+					OnDelete(func(ctx context.Context, cli client.Client) error {
+						_, err := fixtures.GetFeatureTracker(ctx, cli, appNamespace, "dependency-to-clean-up")
+
+						return err
+					}),
+			)
+		})
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// when
+		Expect(featuresHandler.Delete(ctx, envTestClient)).To(Succeed())
+
+		// then
+		_, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "dependency-to-clean-up")
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+	})
+})
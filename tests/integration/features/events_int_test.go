@@ -0,0 +1,97 @@
+package features_test
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/record"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature lifecycle events", func() {
+
+	var (
+		appNamespace string
+		dsci         *dsciv1.DSCInitialization
+		recorder     *record.FakeRecorder
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		appNamespace = envtestutil.AppendRandomNameTo("app-namespace")
+		dsciName := envtestutil.AppendRandomNameTo("dsci-" + appNamespace)
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, appNamespace)
+
+		recorder = record.NewFakeRecorder(100)
+		feature.SetEventRecorder(recorder)
+		DeferCleanup(func() {
+			feature.SetEventRecorder(&record.FakeRecorder{})
+		})
+	})
+
+	receivedEvents := func() []string {
+		var events []string
+		for {
+			select {
+			case event := <-recorder.Events:
+				events = append(events, event)
+			default:
+				return events
+			}
+		}
+	}
+
+	It("should emit events on tracker and owner when feature is applied and removed", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("evented-feature"))
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+		Expect(featuresHandler.Delete(ctx, envTestClient)).To(Succeed())
+
+		// then
+		events := receivedEvents()
+		startedEvent := "Normal " + feature.EventReasonFeatureStarted + " Applying feature [evented-feature]"
+		cleanupEvent := "Normal " + feature.EventReasonCleanupDone + " Removed feature [evented-feature]"
+		// emitted for both FeatureTracker and DSCInitialization
+		Expect(events).To(HaveEach(Or(Equal(startedEvent), Equal(cleanupEvent))))
+		Expect(events).To(HaveLen(4))
+	})
+
+	It("should emit warning with the name of the missing operator", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("requires-operator").
+				PreConditions(feature.EnsureOperatorIsInstalled("missing-operator")),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).ToNot(Succeed())
+
+		// then
+		Expect(receivedEvents()).To(ContainElement(
+			"Warning " + feature.EventReasonMissingOperator + ` Feature [requires-operator] requires operator "missing-operator" which is not installed`,
+		))
+	})
+
+	It("should not emit events when disabled feature has never been applied", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("never-applied"))
+		})
+
+		// when
+		Expect(featuresHandler.Delete(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(receivedEvents()).To(BeEmpty())
+	})
+})
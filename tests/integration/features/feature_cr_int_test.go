@@ -0,0 +1,157 @@
+package features_test
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/features"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature custom resource", func() {
+
+	var (
+		targetNamespace string
+		manifests       *corev1.ConfigMap
+		reconciler      *features.FeatureReconciler
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		targetNamespace = envtestutil.AppendRandomNameTo("feature-cr")
+		Expect(fixtures.CreateOrUpdateNamespace(ctx, envTestClient, fixtures.NewNamespace(targetNamespace))).To(Succeed())
+
+		manifests = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "addon-manifests",
+				Namespace: targetNamespace,
+			},
+			Data: map[string]string{
+				"settings.tmpl.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: addon-settings
+  namespace: {{ .TargetNamespace }}
+data:
+  enabled: "true"
+`,
+			},
+		}
+		Expect(envTestClient.Create(ctx, manifests)).To(Succeed())
+
+		reconciler = &features.FeatureReconciler{
+			Client:            envTestClient,
+			Scheme:            envTestClient.Scheme(),
+			Recorder:          record.NewFakeRecorder(10),
+			OperatorNamespace: targetNamespace,
+		}
+	})
+
+	newFeature := func(ctx context.Context, name string, preconditions ...featurev1.FeaturePreCondition) *featurev1.Feature {
+		instance := &featurev1.Feature{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: featurev1.FeatureSpec{
+				TargetNamespace: targetNamespace,
+				Manifests: []featurev1.ManifestsSource{
+					{ConfigMap: &featurev1.ConfigMapManifests{Name: manifests.Name, Namespace: manifests.Namespace}},
+				},
+				PreConditions: preconditions,
+			},
+		}
+		Expect(envTestClient.Create(ctx, instance)).To(Succeed())
+
+		return instance
+	}
+
+	reconcile := func(ctx context.Context, instance *featurev1.Feature) (ctrl.Result, error) {
+		return reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+	}
+
+	It("should apply manifests from ConfigMap and report the tracker", func(ctx context.Context) {
+		// given
+		instance := newFeature(ctx, envtestutil.AppendRandomNameTo("addon"))
+
+		// when
+		_, err := reconcile(ctx, instance)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		settings := &corev1.ConfigMap{}
+		Expect(envTestClient.Get(ctx, client.ObjectKey{Namespace: targetNamespace, Name: "addon-settings"}, settings)).To(Succeed())
+		Expect(settings.Data).To(HaveKeyWithValue("enabled", "true"))
+
+		Expect(envTestClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+		Expect(instance.Finalizers).To(ContainElement("features.opendatahub.io/finalizer"))
+		Expect(instance.Status.Phase).To(Equal(status.PhaseReady))
+		Expect(instance.Status.TrackerName).To(Equal(targetNamespace + "-" + instance.Name))
+
+		tracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, targetNamespace, instance.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tracker.Spec.Source).To(Equal(featurev1.Source{Type: featurev1.FeatureType, Name: instance.Name}))
+	})
+
+	It("should not read manifests from ConfigMap outside of the operator and applications namespace", func(ctx context.Context) {
+		// given
+		reconciler.OperatorNamespace = "other-namespace"
+		instance := newFeature(ctx, envtestutil.AppendRandomNameTo("addon-foreign"))
+
+		// when
+		_, err := reconcile(ctx, instance)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("manifests can only be read from ConfigMaps in the operator or the applications namespace")))
+
+		settings := &corev1.ConfigMap{}
+		err = envTestClient.Get(ctx, client.ObjectKey{Namespace: targetNamespace, Name: "addon-settings"}, settings)
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should requeue until preconditions are met", func(ctx context.Context) {
+		// given
+		instance := newFeature(ctx, envtestutil.AppendRandomNameTo("addon-waiting"),
+			featurev1.FeaturePreCondition{CRDExists: "missing.addons.opendatahub.io"},
+		)
+
+		// when
+		result, err := reconcile(ctx, instance)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).ToNot(BeZero())
+
+		Expect(envTestClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+		Expect(instance.Status.Phase).To(Equal(status.PhaseProgressing))
+	})
+
+	It("should remove the tracker when the Feature is deleted", func(ctx context.Context) {
+		// given
+		instance := newFeature(ctx, envtestutil.AppendRandomNameTo("addon-removed"))
+		_, err := reconcile(ctx, instance)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		Expect(envTestClient.Delete(ctx, instance)).To(Succeed())
+		_, err = reconcile(ctx, instance)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = fixtures.GetFeatureTracker(ctx, envTestClient, targetNamespace, instance.Name)
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+
+		err = envTestClient.Get(ctx, client.ObjectKeyFromObject(instance), &featurev1.Feature{})
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,108 @@
+package features_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inventory of feature resources", func() {
+	var (
+		testNamespace string
+		namespace     *corev1.Namespace
+		objectCleaner *envtestutil.Cleaner
+		dsci          *dsciv1.DSCInitialization
+		tempDir       string
+	)
+
+	configMapYAML := func(name string) string {
+		return fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: %s
+data:
+  key: value
+`, name, testNamespace)
+	}
+
+	featureWithManifests := func(paths ...string) feature.FeaturesProvider {
+		return func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("inventory-feature").
+				Manifests(
+					manifest.Location(os.DirFS(tempDir)).
+						Include(paths...),
+				),
+			)
+		}
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		objectCleaner = envtestutil.CreateCleaner(envTestClient, envTest.Config, fixtures.Timeout, fixtures.Interval)
+
+		testNamespace = envtestutil.AppendRandomNameTo("test-inventory")
+		dsciName := envtestutil.AppendRandomNameTo("inventory-dsci")
+
+		var err error
+		namespace, err = cluster.CreateNamespace(ctx, envTestClient, testNamespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, testNamespace)
+
+		tempDir = GinkgoT().TempDir()
+		Expect(fixtures.CreateFile(tempDir, "kept.yaml", configMapYAML("kept-cm"))).To(Succeed())
+		Expect(fixtures.CreateFile(tempDir, "removed.yaml", configMapYAML("removed-cm"))).To(Succeed())
+	})
+
+	AfterEach(func(ctx context.Context) {
+		objectCleaner.DeleteAll(ctx, namespace, dsci)
+	})
+
+	It("should record created objects in the FeatureTracker status", func(ctx context.Context) {
+		// when
+		Expect(feature.ClusterFeaturesHandler(dsci, featureWithManifests("kept.yaml", "removed.yaml")).Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		tracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, testNamespace, "inventory-feature")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tracker.Status.Inventory).To(ConsistOf(
+			featurev1.ResourceReference{Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "kept-cm"},
+			featurev1.ResourceReference{Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "removed-cm"},
+		))
+	})
+
+	It("should remove objects which are no longer part of the feature", func(ctx context.Context) {
+		// given
+		Expect(feature.ClusterFeaturesHandler(dsci, featureWithManifests("kept.yaml", "removed.yaml")).Apply(ctx, envTestClient)).To(Succeed())
+
+		// when
+		Expect(feature.ClusterFeaturesHandler(dsci, featureWithManifests("kept.yaml")).Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(envTestClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "kept-cm"}, &corev1.ConfigMap{})).To(Succeed())
+
+		errGet := envTestClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "removed-cm"}, &corev1.ConfigMap{})
+		Expect(k8serr.IsNotFound(errGet)).To(BeTrue())
+
+		tracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, testNamespace, "inventory-feature")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tracker.Status.Inventory).To(ConsistOf(
+			featurev1.ResourceReference{Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "kept-cm"},
+		))
+	})
+})
@@ -6,12 +6,15 @@ import (
 	"path"
 
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/kustomize"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/provider"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
 	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
 	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
 
@@ -132,4 +135,29 @@ metadata:
 		Expect(err).ToNot(HaveOccurred())
 		Expect(realNs.Name).To(Equal("real-file-test-ns"))
 	})
+
+	It("should be able to process kustomization from embedded file system", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			errKustomizeCreate := registry.Add(feature.Define("create-from-kustomization").
+				Manifests(
+					kustomize.Location(fixtures.TestEmbeddedFiles).
+						Path(path.Join(fixtures.BaseDir, "fake-kust-dir")).
+						WithPlugins(plugins.CreateNamespaceApplierPlugin(namespace.Name)),
+				),
+			)
+
+			Expect(errKustomizeCreate).ToNot(HaveOccurred())
+
+			return nil
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		cfgMap := &corev1.ConfigMap{}
+		Expect(envTestClient.Get(ctx, client.ObjectKey{Namespace: namespace.Name, Name: "my-configmap"}, cfgMap)).To(Succeed())
+		Expect(cfgMap.Data).To(HaveKeyWithValue("key", "value"))
+	})
 })
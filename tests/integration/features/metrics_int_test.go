@@ -0,0 +1,130 @@
+package features_test
+
+import (
+	"context"
+	"errors"
+
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature metrics", func() {
+
+	var (
+		appNamespace string
+		dsci         *dsciv1.DSCInitialization
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		appNamespace = envtestutil.AppendRandomNameTo("app-namespace")
+		dsciName := envtestutil.AppendRandomNameTo("dsci-" + appNamespace)
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, appNamespace)
+	})
+
+	It("should count failures by condition reason and expose current phase of the tracker", func(ctx context.Context) {
+		// given
+		featureName := envtestutil.AppendRandomNameTo("failing-precondition")
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define(featureName).
+				PreConditions(func(_ context.Context, _ client.Client, _ *feature.Feature) error {
+					return errors.New("during test always fail")
+				}),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).ToNot(Succeed())
+
+		// then
+		Expect(metricValue("odh_feature_failures_total", map[string]string{
+			"feature": featureName,
+			"reason":  string(featurev1.ConditionReason.PreConditions),
+		})).To(BeNumerically("==", 1))
+		Expect(metricValue("odh_feature_tracker_phase", map[string]string{
+			"feature": featureName,
+			"phase":   status.PhaseError,
+		})).To(BeNumerically("==", 1))
+		Expect(metricValue("odh_feature_tracker_phase", map[string]string{
+			"feature": featureName,
+			"phase":   status.PhaseReady,
+		})).To(BeNumerically("==", 0))
+	})
+
+	It("should measure duration of applying the feature by phase", func(ctx context.Context) {
+		// given
+		featureName := envtestutil.AppendRandomNameTo("measured-feature")
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define(featureName))
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		for _, phase := range []featurev1.FeatureConditionReason{
+			featurev1.ConditionReason.LoadTemplateData,
+			featurev1.ConditionReason.PreConditions,
+			featurev1.ConditionReason.ResourceCreation,
+			featurev1.ConditionReason.ApplyManifests,
+			featurev1.ConditionReason.PostConditions,
+		} {
+			Expect(metricValue("odh_feature_apply_duration_seconds", map[string]string{
+				"feature": featureName,
+				"phase":   string(phase),
+			})).To(BeNumerically("==", 1), "expected single observation of phase %s", phase)
+		}
+	})
+})
+
+// metricValue finds the metric with matching labels in the controller-runtime registry. For histograms it returns the number of observations.
+func metricValue(name string, labels map[string]string) float64 {
+	families, err := metrics.Registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if !hasLabels(metric, labels) {
+				continue
+			}
+
+			switch {
+			case metric.GetCounter() != nil:
+				return metric.GetCounter().GetValue()
+			case metric.GetGauge() != nil:
+				return metric.GetGauge().GetValue()
+			case metric.GetHistogram() != nil:
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+
+	Fail("metric " + name + " not found")
+
+	return 0
+}
+
+func hasLabels(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, label := range metric.GetLabel() {
+		if value, found := labels[label.GetName()]; found && value == label.GetValue() {
+			matched++
+		}
+	}
+
+	return matched == len(labels)
+}
@@ -0,0 +1,71 @@
+package features_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blang/semver/v4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature migrations", func() {
+
+	var (
+		appNamespace string
+		dsci         *dsciv1.DSCInitialization
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		appNamespace = envtestutil.AppendRandomNameTo("app-namespace")
+		dsciName := envtestutil.AppendRandomNameTo("dsci-" + appNamespace)
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, appNamespace)
+	})
+
+	failingMigration := func(_ context.Context, _ client.Client, _ *feature.Feature) error {
+		return errors.New("migration should not be applied")
+	}
+
+	It("should consider newly created feature as migrated to the current release", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("fresh-install").
+				Migrations(cluster.GetRelease().Version.Version, failingMigration),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "fresh-install")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(featureTracker.Status.MigratedVersion).To(Equal(cluster.GetRelease().Version.String()))
+	})
+
+	It("should not apply migrations introduced after the current release to features created by older releases", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("future-migration").
+				Migrations(semver.MustParse("999.0.0"), failingMigration),
+			)
+		})
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "future-migration")
+		Expect(err).ToNot(HaveOccurred())
+		featureTracker.Status.MigratedVersion = ""
+		Expect(envTestClient.Status().Update(ctx, featureTracker)).To(Succeed())
+
+		// then
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+	})
+})
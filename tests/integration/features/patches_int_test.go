@@ -0,0 +1,176 @@
+package features_test
+
+import (
+	"context"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Patching existing resources", func() {
+	var (
+		testNamespace string
+		namespace     *corev1.Namespace
+		objectCleaner *envtestutil.Cleaner
+		dsci          *dsciv1.DSCInitialization
+		tempDir       string
+	)
+
+	const configMapName = "patched-cm"
+
+	getConfigMap := func(ctx context.Context) *corev1.ConfigMap {
+		cfgMap := &corev1.ConfigMap{}
+		Expect(envTestClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: configMapName}, cfgMap)).To(Succeed())
+
+		return cfgMap
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		objectCleaner = envtestutil.CreateCleaner(envTestClient, envTest.Config, fixtures.Timeout, fixtures.Interval)
+
+		testNamespace = envtestutil.AppendRandomNameTo("test-patches")
+		dsciName := envtestutil.AppendRandomNameTo("patches-dsci")
+
+		var err error
+		namespace, err = cluster.CreateNamespace(ctx, envTestClient, testNamespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, testNamespace)
+
+		Expect(envTestClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: testNamespace},
+			Data:       map[string]string{"existing": "value", "to-remove": "value"},
+		})).To(Succeed())
+
+		tempDir = GinkgoT().TempDir()
+		Expect(fixtures.CreateFile(tempDir, "cm.jsonpatch.tmpl.yaml", `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: `+configMapName+`
+  namespace: {{ .TargetNamespace }}
+patch:
+  - op: remove
+    path: /data/to-remove
+  - op: add
+    path: /data/added
+    value: by-json-patch
+`)).To(Succeed())
+		Expect(fixtures.CreateFile(tempDir, "cm.smpatch.tmpl.yaml", `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: `+configMapName+`
+  namespace: {{ .TargetNamespace }}
+data:
+  merged: by-strategic-merge-patch
+`)).To(Succeed())
+		Expect(fixtures.CreateFile(tempDir, "cm-revert.jsonpatch.tmpl.yaml", `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: `+configMapName+`
+  namespace: {{ .TargetNamespace }}
+patch:
+  - op: remove
+    path: /data/added
+`)).To(Succeed())
+	})
+
+	AfterEach(func(ctx context.Context) {
+		objectCleaner.DeleteAll(ctx, namespace, dsci)
+	})
+
+	It("should apply JSON patch operations", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("json-patch").
+				Manifests(manifest.Location(os.DirFS(tempDir)).Include("cm.jsonpatch.tmpl.yaml")),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(getConfigMap(ctx).Data).To(Equal(map[string]string{"existing": "value", "added": "by-json-patch"}))
+	})
+
+	It("should apply strategic merge patch", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("strategic-merge-patch").
+				Manifests(manifest.Location(os.DirFS(tempDir)).Include("cm.smpatch.tmpl.yaml")),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(getConfigMap(ctx).Data).To(HaveKeyWithValue("merged", "by-strategic-merge-patch"))
+		Expect(getConfigMap(ctx).Data).To(HaveKeyWithValue("existing", "value"))
+	})
+
+	It("should apply inverse patch when feature is deleted", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("json-patch-with-revert").
+				Manifests(manifest.Location(os.DirFS(tempDir)).Include("cm.jsonpatch.tmpl.yaml")).
+				OnDeleteManifests(manifest.Location(os.DirFS(tempDir)).Include("cm-revert.jsonpatch.tmpl.yaml")),
+			)
+		})
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+		Expect(getConfigMap(ctx).Data).To(HaveKey("added"))
+
+		// when
+		Expect(featuresHandler.Delete(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(getConfigMap(ctx).Data).ToNot(HaveKey("added"))
+	})
+
+	It("should automatically revert patches when feature is deleted", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("json-patch-reverted-automatically").
+				Manifests(manifest.Location(os.DirFS(tempDir)).Include("cm.jsonpatch.tmpl.yaml", "cm.smpatch.tmpl.yaml")),
+			)
+		})
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+		Expect(getConfigMap(ctx).Data).To(HaveKey("added"))
+		Expect(getConfigMap(ctx).Data).To(HaveKey("merged"))
+
+		// when
+		Expect(featuresHandler.Delete(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(getConfigMap(ctx).Data).To(Equal(map[string]string{"existing": "value", "to-remove": "value"}))
+	})
+
+	It("should keep original state of the resource when feature is applied again", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("strategic-merge-patch-reapplied").
+				Manifests(manifest.Location(os.DirFS(tempDir)).Include("cm.smpatch.tmpl.yaml")),
+			)
+		})
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// when
+		Expect(featuresHandler.Delete(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(getConfigMap(ctx).Data).To(Equal(map[string]string{"existing": "value", "to-remove": "value"}))
+	})
+})
@@ -0,0 +1,124 @@
+package features_test
+
+import (
+	"context"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/provider"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Planning features", func() {
+	var (
+		testNamespace   string
+		namespace       *corev1.Namespace
+		objectCleaner   *envtestutil.Cleaner
+		dsci            *dsciv1.DSCInitialization
+		featuresHandler *feature.FeaturesHandler
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		objectCleaner = envtestutil.CreateCleaner(envTestClient, envTest.Config, fixtures.Timeout, fixtures.Interval)
+
+		testNamespace = envtestutil.AppendRandomNameTo("test-namespace")
+		dsciName := envtestutil.AppendRandomNameTo("test-dsci")
+
+		var err error
+		namespace, err = cluster.CreateNamespace(ctx, envTestClient, testNamespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, testNamespace)
+		dsci.Spec.ServiceMesh.ControlPlane.Namespace = namespace.Name
+
+		featuresHandler = feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define("plan-local-gw-svc").
+					Managed().
+					Manifests(
+						manifest.Location(fixtures.TestEmbeddedFiles).
+							Include(path.Join(fixtures.BaseDir, "local-gateway-svc.tmpl.yaml")),
+					).
+					WithData(feature.Entry("ControlPlane", provider.ValueOf(dsci.Spec.ServiceMesh.ControlPlane).Get)),
+			)
+		})
+	})
+
+	AfterEach(func(ctx context.Context) {
+		objectCleaner.DeleteAll(ctx, namespace, dsci)
+	})
+
+	It("should report resource creation without creating anything in the cluster", func(ctx context.Context) {
+		// when
+		plans, err := featuresHandler.Plan(ctx, envTestClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].Changes).To(ConsistOf(
+			MatchFields(IgnoreExtras, Fields{
+				"Action":    Equal(resource.ActionCreate),
+				"Name":      Equal("knative-local-gateway"),
+				"Namespace": Equal(testNamespace),
+			}),
+		))
+
+		_, errSvc := fixtures.GetService(ctx, envTestClient, testNamespace, "knative-local-gateway")
+		Expect(k8serr.IsNotFound(errSvc)).To(BeTrue())
+
+		_, errTracker := fixtures.GetFeatureTracker(ctx, envTestClient, testNamespace, "plan-local-gw-svc")
+		Expect(k8serr.IsNotFound(errTracker)).To(BeTrue())
+	})
+
+	It("should report no changes when resource is already applied", func(ctx context.Context) {
+		// given
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// when
+		plans, err := featuresHandler.Plan(ctx, envTestClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].Changes).To(ConsistOf(
+			MatchFields(IgnoreExtras, Fields{
+				"Action": Equal(resource.ActionNoOp),
+				"Name":   Equal("knative-local-gateway"),
+			}),
+		))
+	})
+
+	It("should report patch with a diff when resource has been modified", func(ctx context.Context) {
+		// given
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+		service, err := fixtures.GetService(ctx, envTestClient, testNamespace, "knative-local-gateway")
+		Expect(err).ToNot(HaveOccurred())
+		service.Annotations["test"] = "new-value"
+		Expect(envTestClient.Update(ctx, service)).To(Succeed())
+
+		// when
+		plans, err := featuresHandler.Plan(ctx, envTestClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].Changes).To(ConsistOf(
+			MatchFields(IgnoreExtras, Fields{
+				"Action": Equal(resource.ActionPatch),
+				"Diff":   ContainSubstring(`"test":"original-value"`),
+			}),
+		))
+	})
+})
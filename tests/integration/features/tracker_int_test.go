@@ -119,6 +119,51 @@ var _ = Describe("Feature tracking capability", func() {
 				}),
 			))
 		})
+
+		It("should indicate progress when post-condition of non-blocking feature is not met yet", func(ctx context.Context) {
+			// given
+			featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+				errFeatureAdd := registry.Add(
+					feature.Define("non-blocking-post-condition").
+						NonBlocking().
+						PostConditions(feature.WaitForPodsToBeReady(appNamespace)),
+					feature.Define("dependent-on-non-blocking").
+						DependsOn("non-blocking-post-condition"),
+				)
+
+				Expect(errFeatureAdd).ToNot(HaveOccurred())
+
+				return nil
+			})
+
+			// when
+			applyErr := featuresHandler.Apply(ctx, envTestClient)
+
+			// then
+			_, notReady := feature.IsNotReady(applyErr)
+			Expect(notReady).To(BeTrue())
+
+			featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "non-blocking-post-condition")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(featureTracker.Status.Phase).To(Equal(status.PhaseProgressing))
+			Expect(featureTracker.Status.Conditions).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(conditionsv1.ConditionProgressing),
+					"Status": Equal(corev1.ConditionTrue),
+					"Reason": Equal(string(featurev1.ConditionReason.PostConditions)),
+				}),
+			))
+
+			dependentTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "dependent-on-non-blocking")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dependentTracker.Status.Phase).To(Equal(status.PhaseProgressing))
+			Expect(dependentTracker.Status.Conditions).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(conditionsv1.ConditionProgressing),
+					"Reason": Equal(string(featurev1.ConditionReason.DependencyNotReady)),
+				}),
+			))
+		})
 	})
 
 	Context("adding metadata of FeatureTracker origin", func() {
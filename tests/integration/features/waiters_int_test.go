@@ -0,0 +1,74 @@
+package features_test
+
+import (
+	"context"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Waiting for resources", func() {
+
+	var (
+		appNamespace string
+		dsci         *dsciv1.DSCInitialization
+		retryPolicy  feature.RetryPolicy
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		appNamespace = envtestutil.AppendRandomNameTo("app-namespace")
+		dsciName := envtestutil.AppendRandomNameTo("dsci-" + appNamespace)
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, appNamespace)
+		retryPolicy = feature.RetryPolicy{Interval: fixtures.Interval, Timeout: fixtures.Timeout}
+
+		_, err := cluster.CreateNamespace(ctx, envTestClient, appNamespace)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should wait for CRD to be established", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("crd-established").
+				RetryPolicy(retryPolicy).
+				PreConditions(feature.WaitForCRDEstablished("featuretrackers.features.opendatahub.io")),
+			)
+		})
+
+		// then
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+	})
+
+	It("should report observed state of the awaited resource in FeatureTracker", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("crd-missing").
+				NonBlocking().
+				PreConditions(feature.WaitForCRDEstablished("missing.addons.opendatahub.io")),
+			)
+		})
+
+		// when
+		applyErr := featuresHandler.Apply(ctx, envTestClient)
+
+		// then
+		Expect(applyErr).To(MatchError(ContainSubstring("CustomResourceDefinition not found")))
+
+		featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "crd-missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(featureTracker.Status.Conditions).To(ContainElement(
+			MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal(conditionsv1.ConditionProgressing),
+				"Message": ContainSubstring("CustomResourceDefinition not found"),
+			}),
+		))
+	})
+})
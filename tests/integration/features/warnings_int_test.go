@@ -0,0 +1,90 @@
+package features_test
+
+import (
+	"context"
+	"errors"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/integration/features/fixtures"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Feature warnings", func() {
+
+	var (
+		appNamespace string
+		dsci         *dsciv1.DSCInitialization
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		appNamespace = envtestutil.AppendRandomNameTo("app-namespace")
+		dsciName := envtestutil.AppendRandomNameTo("dsci-" + appNamespace)
+		dsci = fixtures.NewDSCInitialization(ctx, envTestClient, dsciName, appNamespace)
+	})
+
+	failingCheck := func(_ context.Context, _ client.Client, _ *feature.Feature) error {
+		return errors.New("custom domain does not resolve")
+	}
+
+	It("should apply the feature and report failed warning as degraded condition", func(ctx context.Context) {
+		// given
+		featuresHandler := feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+			return registry.Add(feature.Define("feature-with-warnings").
+				Warnings(failingCheck),
+			)
+		})
+
+		// when
+		Expect(featuresHandler.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		featureTracker, err := fixtures.GetFeatureTracker(ctx, envTestClient, appNamespace, "feature-with-warnings")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(featureTracker.Status.Phase).To(Equal(status.PhaseReady))
+		Expect(featureTracker.Status.Conditions).To(ContainElement(
+			MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal(conditionsv1.ConditionDegraded),
+				"Status":  Equal(corev1.ConditionTrue),
+				"Reason":  Equal(string(featurev1.ConditionReason.Warnings)),
+				"Message": ContainSubstring("custom domain does not resolve"),
+			}),
+		))
+	})
+
+	It("should surface warnings to the reporter of the capability", func(ctx context.Context) {
+		// given
+		var reportedErr error
+		reporter := status.NewStatusReporter(envTestClient, dsci, func(err error) status.SaveStatusFunc[*dsciv1.DSCInitialization] {
+			reportedErr = err
+
+			return func(_ *dsciv1.DSCInitialization) {}
+		})
+
+		capability := feature.NewHandlerWithReporter(
+			feature.ClusterFeaturesHandler(dsci, func(registry feature.FeaturesRegistry) error {
+				return registry.Add(feature.Define("capability-with-warnings").
+					Warnings(failingCheck),
+				)
+			}),
+			reporter,
+		)
+
+		// when
+		Expect(capability.Apply(ctx, envTestClient)).To(Succeed())
+
+		// then
+		Expect(feature.IsWarning(reportedErr)).To(BeTrue())
+		Expect(reportedErr).To(MatchError(ContainSubstring("custom domain does not resolve")))
+	})
+})
@@ -0,0 +1,13 @@
+package templates_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEmbeddedTemplates(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Embedded feature templates")
+}
@@ -0,0 +1,125 @@
+package templates_test
+
+import (
+	"context"
+	"io/fs"
+	"path"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/dscinitialization"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/provider"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/serverless"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/servicemesh"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Renders all the templates embedded in the operator with sample configuration, so broken templates are caught
+// before they reach a cluster, where they would fail in LoadTemplateData or ApplyManifests phases of the feature.
+// Templates of each feature are rendered only with the data the feature defines, so templates referring to keys
+// defined by other features are reported as well.
+var _ = Describe("Embedded feature templates", func() {
+
+	dsciSpec := &dsciv1.DSCInitializationSpec{
+		ApplicationsNamespace: "opendatahub",
+		ServiceMesh: &infrav1.ServiceMeshSpec{
+			ManagementState: operatorv1.Managed,
+			ControlPlane: infrav1.ControlPlaneSpec{
+				Name:              "data-science-smcp",
+				Namespace:         "istio-system",
+				MetricsCollection: "Istio",
+			},
+			Auth: infrav1.AuthSpec{
+				Audiences: &[]string{"https://kubernetes.default.svc"},
+			},
+		},
+	}
+
+	servingSpec := &infrav1.ServingSpec{
+		ManagementState: operatorv1.Managed,
+		Name:            "knative-serving",
+		IngressGateway: infrav1.GatewaySpec{
+			Domain: "*.apps.example.com",
+			Certificate: infrav1.CertificateSpec{
+				Type: infrav1.OpenshiftDefaultIngress,
+			},
+		},
+	}
+
+	// Data is defined the same way as in the features using the templates.
+	controlPlane := entry(servicemesh.FeatureData.ControlPlane.Define(dsciSpec))
+	authorization := []dataEntry{
+		entry(servicemesh.FeatureData.Authorization.Spec.Define(dsciSpec)),
+		entry(servicemesh.FeatureData.Authorization.Namespace.Define(dsciSpec)),
+		entry(servicemesh.FeatureData.Authorization.Provider.Define(dsciSpec)),
+		entry(servicemesh.FeatureData.Authorization.ExtensionProviderName.Define(dsciSpec)),
+	}
+	domain := entry(feature.DataEntry[string]{Key: "Domain", Value: provider.ValueOf("apps.example.com").OrElse("")})
+	serving := entry(serverless.FeatureData.Serving.Define(servingSpec))
+	certificateName := entry(serverless.FeatureData.CertificateName.Define(servingSpec))
+	ingressDomain := entry(serverless.FeatureData.IngressDomain.Define(servingSpec))
+
+	DescribeTable("should render templates with data defined by the feature",
+		func(ctx context.Context, location fs.FS, paths []string, entries []dataEntry) {
+			data := map[string]any{"TargetNamespace": dsciSpec.ApplicationsNamespace}
+			for _, add := range entries {
+				add(ctx, data)
+			}
+
+			Expect(manifest.Validate(location, data, paths...)).To(Succeed())
+		},
+		Entry("mesh-control-plane-creation", dscinitialization.Templates.Location,
+			[]string{dscinitialization.Templates.ServiceMeshDir},
+			[]dataEntry{controlPlane}),
+		Entry("mesh-metrics-collection", dscinitialization.Templates.Location,
+			[]string{dscinitialization.Templates.MetricsDir},
+			[]dataEntry{controlPlane}),
+		Entry("mesh-control-plane-external-authz", dscinitialization.Templates.Location,
+			[]string{
+				path.Join(dscinitialization.Templates.AuthorinoDir, "auth-smm.tmpl.yaml"),
+				path.Join(dscinitialization.Templates.AuthorinoDir, "base"),
+				path.Join(dscinitialization.Templates.AuthorinoDir, "mesh-authz-ext-provider.patch.tmpl.yaml"),
+			},
+			append([]dataEntry{controlPlane}, authorization...)),
+		Entry("enable-proxy-injection-in-authorino-deployment", dscinitialization.Templates.Location,
+			[]string{path.Join(dscinitialization.Templates.AuthorinoDir, "deployment.injection.patch.tmpl.yaml")},
+			append([]dataEntry{controlPlane}, authorization...)),
+		Entry("kserve-external-authz", kserve.Resources.Location,
+			[]string{
+				path.Join(kserve.Resources.ServiceMeshDir, "activator-envoyfilter.tmpl.yaml"),
+				path.Join(kserve.Resources.ServiceMeshDir, "envoy-oauth-temp-fix.tmpl.yaml"),
+				path.Join(kserve.Resources.ServiceMeshDir, "kserve-predictor-authorizationpolicy.tmpl.yaml"),
+				path.Join(kserve.Resources.ServiceMeshDir, "migrations", "kserve-predictor-authorizationpolicy.patch.tmpl.yaml"),
+			},
+			append([]dataEntry{domain, controlPlane}, authorization...)),
+		Entry("serverless-serving-deployment", kserve.Resources.Location,
+			[]string{kserve.Resources.InstallDir},
+			[]dataEntry{ingressDomain, serving, controlPlane}),
+		Entry("serverless-net-istio-secret-filtering", kserve.Resources.Location,
+			[]string{path.Join(kserve.Resources.BaseDir, "serving-net-istio-secret-filtering.patch.tmpl.yaml")},
+			[]dataEntry{serving}),
+		Entry("serverless-serving-gateways", kserve.Resources.Location,
+			[]string{kserve.Resources.GatewaysDir},
+			[]dataEntry{ingressDomain, certificateName, serving, controlPlane}),
+	)
+})
+
+// dataEntry adds the resolved value of the feature data entry to the data used to render templates.
+type dataEntry func(ctx context.Context, data map[string]any)
+
+// entry resolves the value of the data entry. Sample configuration is complete, so no lookups in the cluster are needed.
+func entry[T any](definition feature.DataEntry[T]) dataEntry {
+	return func(ctx context.Context, data map[string]any) {
+		value, err := definition.Value(ctx, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		data[definition.Key] = value
+	}
+}